@@ -0,0 +1,38 @@
+package carsxe
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrEndpointNotAllowed is returned for calls to endpoints missing from the
+// list given to WithAllowedEndpoints.
+var ErrEndpointNotAllowed = errors.New("carsxe: endpoint not allowed")
+
+// WithAllowedEndpoints restricts the client to the given endpoints, written
+// as in the API paths (e.g. "specs", "v1/recalls"). Any other call fails with
+// ErrEndpointNotAllowed before a request is made. Calling it again adds to
+// the list.
+func WithAllowedEndpoints(endpoints ...string) Option {
+	return func(c *Client) {
+		if c.allowed == nil {
+			c.allowed = map[string]bool{}
+		}
+		for _, e := range endpoints {
+			c.allowed[strings.Trim(e, "/")] = true
+		}
+	}
+}
+
+// checkAllowed enforces WithAllowedEndpoints.
+func (c *Client) checkAllowed(endpoint string) error {
+	if c.allowed == nil {
+		return nil
+	}
+	endpoint = strings.Trim(endpoint, "/")
+	if !c.allowed[endpoint] {
+		return fmt.Errorf("%w: %s", ErrEndpointNotAllowed, endpoint)
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package carsxe
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// AuditEntry describes one API call sent over the network, for audit trails
+// and billing reconciliation.
+type AuditEntry struct {
+	Time   time.Time `json:"time"`
+	Method string    `json:"method"`
+	// Endpoint is the API path without a leading slash, e.g. "specs".
+	Endpoint string `json:"endpoint"`
+	// Params holds the query parameters without the API key, with VINs
+	// redacted under WithVINRedaction.
+	Params map[string]string `json:"params,omitempty"`
+	// Status is the HTTP status of the last attempt, 0 if none was received.
+	Status   int           `json:"status"`
+	Duration time.Duration `json:"duration"`
+	Attempts int           `json:"attempts"`
+	// Cost holds the credit and quota headers of the response, such as
+	// X-Credits-Used, as received.
+	Cost map[string]string `json:"cost,omitempty"`
+	// Error is the call's error message, with the API key masked and VINs
+	// redacted under WithVINRedaction.
+	Error string `json:"error,omitempty"`
+}
+
+// AuditSink receives an AuditEntry for every call made by a client
+// configured with WithAuditSink. Record is called synchronously once the
+// call completes, so slow sinks should be wrapped with NewAsyncAuditSink. It
+// must be safe for concurrent use.
+type AuditSink interface {
+	Record(ctx context.Context, e AuditEntry) error
+}
+
+// WithAuditSink reports every call that reached the network to sink,
+// including failed and retried ones. Responses served from the cache,
+// followers of WithSingleflight and WithDryRun calls are not billed and not
+// reported. Errors returned by sink are logged as warnings.
+func WithAuditSink(sink AuditSink) Option {
+	return func(c *Client) { c.auditSink = sink }
+}
+
+// costHeaderPrefixes selects the response headers copied to AuditEntry.Cost.
+var costHeaderPrefixes = []string{"X-Credits-", "X-Quota-", "X-Ratelimit-", "X-Cost"}
+
+// audit reports a completed call to the client's AuditSink.
+func (c *Client) audit(req *http.Request, endpoint string, resp *Response, start time.Time, attempts int, err error) {
+	if c.auditSink == nil || attempts == 0 {
+		return
+	}
+	e := AuditEntry{
+		Time:     start,
+		Method:   req.Method,
+		Endpoint: strings.Trim(endpoint, "/"),
+		Duration: time.Since(start),
+		Attempts: attempts,
+	}
+	if q := c.redactQuery(req.URL.Query()); len(q) > 0 {
+		e.Params = make(map[string]string, len(q))
+		for k := range q {
+			e.Params[k] = q.Get(k)
+		}
+	}
+	if resp != nil {
+		e.Status = resp.StatusCode
+		for name, vs := range resp.Header {
+			for _, prefix := range costHeaderPrefixes {
+				if strings.HasPrefix(name, prefix) && len(vs) > 0 {
+					if e.Cost == nil {
+						e.Cost = map[string]string{}
+					}
+					e.Cost[name] = vs[0]
+				}
+			}
+		}
+	}
+	if err != nil {
+		e.Error = c.redactText(req, err.Error())
+	}
+	ctx := context.WithoutCancel(req.Context())
+	if serr := c.auditSink.Record(ctx, e); serr != nil && c.logger != nil {
+		c.logger.LogAttrs(ctx, slog.LevelWarn, "carsxe: audit sink failed",
+			slog.String(LogKeyEndpoint, e.Endpoint), slog.String(LogKeyError, serr.Error()))
+	}
+}
+
+// JSONLinesSink is an AuditSink writing each entry as one line of JSON.
+type JSONLinesSink struct {
+	mu  sync.Mutex
+	w   io.Writer
+	enc *json.Encoder
+}
+
+// NewJSONLinesSink returns a sink writing to w.
+func NewJSONLinesSink(w io.Writer) *JSONLinesSink {
+	return &JSONLinesSink{w: w, enc: json.NewEncoder(w)}
+}
+
+// OpenAuditFile returns a JSONLinesSink appending to the file at path,
+// creating it if needed. Close closes the file.
+func OpenAuditFile(path string) (*JSONLinesSink, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return NewJSONLinesSink(f), nil
+}
+
+// Record writes e.
+func (s *JSONLinesSink) Record(_ context.Context, e AuditEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.enc.Encode(e)
+}
+
+// Close closes the underlying writer if it is an io.Closer.
+func (s *JSONLinesSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if c, ok := s.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// ErrAuditBufferFull is returned by AsyncAuditSink.Record when its buffer
+// is full and the entry was dropped.
+var ErrAuditBufferFull = errors.New("carsxe: audit buffer full, entry dropped")
+
+// AsyncAuditSink hands entries to another sink from a background goroutine,
+// so a slow sink does not delay calls. Entries arriving while the buffer is
+// full are dropped and counted.
+type AsyncAuditSink struct {
+	sink    AuditSink
+	entries chan AuditEntry
+	done    chan struct{}
+	closeMu sync.RWMutex
+	closed  bool
+	dropped atomic.Int64
+
+	errMu sync.Mutex
+	err   error
+}
+
+// NewAsyncAuditSink starts forwarding entries to sink, buffering up to
+// buffer of them (minimum 1). Close must be called to flush and stop it.
+func NewAsyncAuditSink(sink AuditSink, buffer int) *AsyncAuditSink {
+	a := &AsyncAuditSink{
+		sink:    sink,
+		entries: make(chan AuditEntry, max(buffer, 1)),
+		done:    make(chan struct{}),
+	}
+	go a.run()
+	return a
+}
+
+// Record queues e, or returns ErrAuditBufferFull if the buffer is full.
+// Entries recorded after Close are dropped.
+func (a *AsyncAuditSink) Record(_ context.Context, e AuditEntry) error {
+	a.closeMu.RLock()
+	defer a.closeMu.RUnlock()
+	if a.closed {
+		a.dropped.Add(1)
+		return ErrAuditBufferFull
+	}
+	select {
+	case a.entries <- e:
+		return nil
+	default:
+		a.dropped.Add(1)
+		return ErrAuditBufferFull
+	}
+}
+
+// Dropped returns the number of entries dropped so far.
+func (a *AsyncAuditSink) Dropped() int64 { return a.dropped.Load() }
+
+// Close waits for the queued entries to be written, or for ctx to be done,
+// and returns the first error of the underlying sink. It does not close the
+// underlying sink.
+func (a *AsyncAuditSink) Close(ctx context.Context) error {
+	ctx = ctxOrBackground(ctx)
+	a.closeMu.Lock()
+	if !a.closed {
+		a.closed = true
+		close(a.entries)
+	}
+	a.closeMu.Unlock()
+	select {
+	case <-a.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	a.errMu.Lock()
+	defer a.errMu.Unlock()
+	return a.err
+}
+
+func (a *AsyncAuditSink) run() {
+	defer close(a.done)
+	for e := range a.entries {
+		if err := a.sink.Record(context.Background(), e); err != nil {
+			a.errMu.Lock()
+			if a.err == nil {
+				a.err = err
+			}
+			a.errMu.Unlock()
+		}
+	}
+}
@@ -0,0 +1,319 @@
+package carsxe
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultBatchConcurrency is used when BatchOptions.Concurrency is unset.
+const defaultBatchConcurrency = 4
+
+// BatchResult is the outcome of processing a single VIN in a batch.
+type BatchResult struct {
+	// Index is the position of VIN in the input slice.
+	Index  int
+	VIN    string
+	Result map[string]any
+	Err    error
+}
+
+// BatchOptions configures BatchProcess and the streaming batch helpers.
+type BatchOptions struct {
+	// Concurrency bounds the number of VINs processed at once (default 4).
+	Concurrency int
+	// OnCheckpoint, when set, is called with a cursor each time the prefix of
+	// VINs whose results the caller has handled grows. A result counts as
+	// handled once the caller comes back to receive the next one, so a
+	// cursor never covers a result that may still be in progress and a job
+	// resumed after a crash redoes it. The last result is never covered:
+	// the closing of the channel reports that the batch is complete. Store
+	// the cursor to make a job resumable; nothing is persisted by the
+	// package itself.
+	OnCheckpoint func(cursor string)
+	// ResumeFrom skips the VINs covered by a cursor previously handed to
+	// OnCheckpoint. The same vins slice must be passed again.
+	ResumeFrom string
+	// OnProgress, when set, is called after each result is received from
+	// the channel with the number of VINs done so far, counting any skipped
+	// by ResumeFrom, and the total.
+	OnProgress func(done, total int)
+}
+
+// BatchFunc processes a single VIN as part of a batch.
+type BatchFunc func(ctx context.Context, vin string) (map[string]any, error)
+
+// batchCursorPrefix versions the cursor format.
+const batchCursorPrefix = "carsxe-batch:v1:"
+
+// ParseBatchCursor returns the number of VINs a checkpoint cursor covers.
+func ParseBatchCursor(cursor string) (int, error) {
+	rest, ok := strings.CutPrefix(cursor, batchCursorPrefix)
+	if !ok {
+		return 0, fmt.Errorf("carsxe: invalid batch cursor %q", cursor)
+	}
+	n, err := strconv.Atoi(rest)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("carsxe: invalid batch cursor %q", cursor)
+	}
+	return n, nil
+}
+
+func batchCursor(n int) string {
+	return batchCursorPrefix + strconv.Itoa(n)
+}
+
+// BatchProcess runs fn for every VIN using a bounded worker pool and streams
+// the results on the returned channel in completion order. The channel is
+// closed once every VIN has been processed, or as soon as the in-flight VINs
+// finish after ctx is cancelled; VINs that were never started produce no
+// result. If opts.ResumeFrom is not a valid cursor, the channel yields a
+// single result with Index -1 carrying the error.
+func BatchProcess(ctx context.Context, vins []string, fn BatchFunc, opts BatchOptions) <-chan BatchResult {
+	ctx = ctxOrBackground(ctx)
+	out := make(chan BatchResult)
+
+	first := 0
+	if opts.ResumeFrom != "" {
+		n, err := ParseBatchCursor(opts.ResumeFrom)
+		if err == nil && n > len(vins) {
+			err = fmt.Errorf("carsxe: batch cursor %q is beyond the %d VINs given", opts.ResumeFrom, len(vins))
+		}
+		if err != nil {
+			go func() {
+				defer close(out)
+				select {
+				case out <- BatchResult{Index: -1, Err: err}:
+				case <-ctx.Done():
+				}
+			}()
+			return out
+		}
+		first = n
+	}
+
+	workers := opts.Concurrency
+	if workers <= 0 {
+		workers = defaultBatchConcurrency
+	}
+	if workers > len(vins)-first {
+		workers = len(vins) - first
+	}
+
+	jobs := make(chan int)
+	results := make(chan BatchResult, workers)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				res, err := fn(ctx, vins[i])
+				select {
+				case results <- BatchResult{Index: i, VIN: vins[i], Result: res, Err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(results)
+		defer wg.Wait()
+		defer close(jobs)
+		for i := first; i < len(vins); i++ {
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// Forward results and track the handled prefix for checkpoints. out is
+	// unbuffered, so once the caller receives a result it is done with the
+	// previous one.
+	go func() {
+		defer close(out)
+		done := make([]bool, len(vins))
+		next := first
+		completed := 0
+		handling := -1
+		for r := range results {
+			select {
+			case out <- r:
+			case <-ctx.Done():
+				for range results {
+				}
+				return
+			}
+			completed++
+			if opts.OnProgress != nil {
+				opts.OnProgress(first+completed, len(vins))
+			}
+			prev := handling
+			handling = r.Index
+			if opts.OnCheckpoint == nil || prev < 0 {
+				continue
+			}
+			done[prev] = true
+			advanced := false
+			for next < len(vins) && done[next] {
+				next++
+				advanced = true
+			}
+			if advanced {
+				opts.OnCheckpoint(batchCursor(next))
+			}
+		}
+	}()
+	return out
+}
+
+// StreamSpecs decodes vins with the Specs endpoint concurrently, streaming
+// results as they complete. callOpts apply to every request; see
+// BatchProcess for the channel semantics.
+func (c *Client) StreamSpecs(ctx context.Context, vins []string, opts BatchOptions, callOpts ...CallOption) <-chan BatchResult {
+	return BatchProcess(ctx, vins, func(ctx context.Context, vin string) (map[string]any, error) {
+		return c.SpecsContext(ctx, map[string]string{"vin": vin}, callOpts...)
+	}, opts)
+}
+
+// BatchSpecs decodes vins with the Specs endpoint using BatchProcess and
+// returns every result, including per-VIN errors, in input order. VINs left
+// out by a cancelled ctx or by opts.ResumeFrom are omitted, and ctx's error
+// is returned. An invalid opts.ResumeFrom is returned as the error.
+func (c *Client) BatchSpecs(ctx context.Context, vins []string, opts BatchOptions, callOpts ...CallOption) ([]BatchResult, error) {
+	return c.batchCollect(ctx, vins, opts, c.SpecsContext, callOpts)
+}
+
+// BatchHistory is BatchSpecs for the History endpoint.
+func (c *Client) BatchHistory(ctx context.Context, vins []string, opts BatchOptions, callOpts ...CallOption) ([]BatchResult, error) {
+	return c.batchCollect(ctx, vins, opts, c.HistoryContext, callOpts)
+}
+
+// BatchMarketValue is BatchSpecs for the MarketValue endpoint.
+func (c *Client) BatchMarketValue(ctx context.Context, vins []string, opts BatchOptions, callOpts ...CallOption) ([]BatchResult, error) {
+	return c.batchCollect(ctx, vins, opts, c.MarketValueContext, callOpts)
+}
+
+// batchCollect runs endpoint for every VIN and gathers the results.
+func (c *Client) batchCollect(ctx context.Context, vins []string, opts BatchOptions,
+	endpoint func(context.Context, map[string]string, ...CallOption) (map[string]any, error), callOpts []CallOption) ([]BatchResult, error) {
+	ctx = ctxOrBackground(ctx)
+	var out []BatchResult
+	for r := range BatchProcess(ctx, vins, func(ctx context.Context, vin string) (map[string]any, error) {
+		return endpoint(ctx, map[string]string{"vin": vin}, callOpts...)
+	}, opts) {
+		if r.Index < 0 {
+			return nil, r.Err
+		}
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Index < out[j].Index })
+	return out, ctx.Err()
+}
+
+// BulkResult pairs a VIN with its Specs result or error.
+type BulkResult struct {
+	VIN    string
+	Result map[string]any
+	Err    error
+}
+
+// BulkSpecs decodes vins with the Specs endpoint using at most concurrency
+// requests at once and returns the results in input order. Once ctx is
+// cancelled no new requests are started; the returned slice still covers
+// every VIN, those that were not processed carry ctx's error, and ctx's
+// error is returned alongside it.
+func (c *Client) BulkSpecs(ctx context.Context, vins []string, concurrency int, opts ...CallOption) ([]BulkResult, error) {
+	ctx = ctxOrBackground(ctx)
+	out := make([]BulkResult, len(vins))
+	done := make([]bool, len(vins))
+	for r := range c.StreamSpecs(ctx, vins, BatchOptions{Concurrency: concurrency}, opts...) {
+		out[r.Index] = BulkResult{VIN: r.VIN, Result: r.Result, Err: r.Err}
+		done[r.Index] = true
+	}
+	err := ctx.Err()
+	for i, ok := range done {
+		if !ok {
+			out[i] = BulkResult{VIN: vins[i], Err: err}
+		}
+	}
+	return out, err
+}
+
+// ndjsonFlushEvery bounds how many lines ExportNDJSON buffers before
+// flushing, and ndjsonFlushInterval how long a line may sit in the buffer.
+const (
+	ndjsonFlushEvery    = 100
+	ndjsonFlushInterval = time.Second
+)
+
+// ndjsonLine is the shape of a single ExportNDJSON record.
+type ndjsonLine struct {
+	VIN    string         `json:"vin"`
+	Result map[string]any `json:"result,omitempty"`
+	Error  string         `json:"error,omitempty"`
+}
+
+// ExportNDJSON writes each result received on results to w as one JSON
+// object per line, containing the VIN, the result and the error message if
+// any. Output is flushed every 100 lines and at least once a second while
+// lines are pending; if w is an http.Flusher it is flushed too.
+// ExportNDJSON returns when results is closed or on the first write error, in
+// which case the caller should cancel the producing batch.
+func ExportNDJSON(w io.Writer, results <-chan BatchResult) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	flush := func() error {
+		if err := bw.Flush(); err != nil {
+			return err
+		}
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+		return nil
+	}
+
+	ticker := time.NewTicker(ndjsonFlushInterval)
+	defer ticker.Stop()
+	pending := 0
+	for {
+		select {
+		case r, ok := <-results:
+			if !ok {
+				return flush()
+			}
+			line := ndjsonLine{VIN: r.VIN, Result: r.Result}
+			if r.Err != nil {
+				line.Error = r.Err.Error()
+			}
+			if err := enc.Encode(line); err != nil {
+				return err
+			}
+			pending++
+			if pending < ndjsonFlushEvery {
+				continue
+			}
+		case <-ticker.C:
+			if pending == 0 {
+				continue
+			}
+		}
+		if err := flush(); err != nil {
+			return err
+		}
+		pending = 0
+	}
+}
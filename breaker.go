@@ -0,0 +1,179 @@
+package carsxe
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned without contacting the API while the circuit
+// breaker set up by WithCircuitBreaker is open.
+var ErrCircuitOpen = errors.New("carsxe: circuit breaker is open")
+
+// errNotSent tells a breaker that an allowed request was never sent.
+var errNotSent = errors.New("carsxe: request not sent")
+
+// CircuitState is the state of a circuit breaker.
+type CircuitState int
+
+const (
+	// CircuitClosed lets requests through; it is also reported when no
+	// breaker is configured.
+	CircuitClosed CircuitState = iota
+	// CircuitOpen rejects requests with ErrCircuitOpen.
+	CircuitOpen
+	// CircuitHalfOpen lets a limited number of probe requests through to
+	// find out whether the API has recovered.
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// CircuitBreakerConfig configures WithCircuitBreaker. Zero fields take their
+// defaults.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failed attempts that
+	// opens the breaker (default 5).
+	FailureThreshold int
+	// OpenDuration is how long the breaker stays open before letting probes
+	// through (default 30s).
+	OpenDuration time.Duration
+	// HalfOpenProbes is the number of concurrent probe requests allowed
+	// while half-open (default 1). A successful probe closes the breaker, a
+	// failed one opens it again.
+	HalfOpenProbes int
+}
+
+// WithCircuitBreaker stops sending requests after repeated upstream
+// failures, so that an outage fails calls fast with ErrCircuitOpen instead
+// of waiting for timeouts and retries. Network errors and 5xx responses
+// count as failures; other responses, including 4xx, count as successes and
+// a call cancelled by its context counts as neither. Every attempt made
+// under WithRetry is counted, and retrying stops once the breaker opens.
+// Cached responses are served regardless of the breaker's state.
+func WithCircuitBreaker(cfg CircuitBreakerConfig) Option {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = 5
+	}
+	if cfg.OpenDuration <= 0 {
+		cfg.OpenDuration = 30 * time.Second
+	}
+	if cfg.HalfOpenProbes <= 0 {
+		cfg.HalfOpenProbes = 1
+	}
+	return func(c *Client) { c.breaker = &circuitBreaker{cfg: cfg} }
+}
+
+// CircuitState returns the current state of the client's circuit breaker,
+// for health checks.
+func (c *Client) CircuitState() CircuitState {
+	return c.breaker.currentState()
+}
+
+type circuitBreaker struct {
+	cfg CircuitBreakerConfig
+
+	mu       sync.Mutex
+	state    CircuitState
+	gen      uint64 // incremented on every state change
+	failures int
+	openedAt time.Time
+	probes   int
+}
+
+// allow reports whether a request may be sent. On success the caller must
+// pass the request's outcome to done. A nil breaker allows everything.
+func (b *circuitBreaker) allow() (done func(err error), err error) {
+	if b == nil {
+		return func(error) {}, nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advance()
+	gen := b.gen
+	switch b.state {
+	case CircuitOpen:
+		return nil, ErrCircuitOpen
+	case CircuitHalfOpen:
+		if b.probes >= b.cfg.HalfOpenProbes {
+			return nil, ErrCircuitOpen
+		}
+		b.probes++
+	}
+	return func(err error) { b.record(err, gen) }, nil
+}
+
+// record updates the breaker with the outcome of a request allowed during
+// generation gen. Outcomes of requests allowed before the last state change
+// are ignored, so a late success cannot close a breaker that has opened
+// since, and only probes move it from half-open to closed.
+func (b *circuitBreaker) record(err error, gen uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if gen != b.gen {
+		return
+	}
+	if b.state == CircuitHalfOpen {
+		b.probes--
+	}
+	if errors.Is(err, errNotSent) || errors.Is(err, context.Canceled) {
+		return
+	}
+	if !breakerFailure(err) {
+		b.failures = 0
+		if b.state == CircuitHalfOpen {
+			b.setState(CircuitClosed)
+		}
+		return
+	}
+	b.failures++
+	if b.state == CircuitHalfOpen || b.failures >= b.cfg.FailureThreshold {
+		b.setState(CircuitOpen)
+		b.openedAt = time.Now()
+	}
+}
+
+// advance moves an open breaker to half-open once OpenDuration has passed.
+func (b *circuitBreaker) advance() {
+	if b.state == CircuitOpen && time.Since(b.openedAt) >= b.cfg.OpenDuration {
+		b.setState(CircuitHalfOpen)
+	}
+}
+
+// setState moves the breaker to s, starting a new generation.
+func (b *circuitBreaker) setState(s CircuitState) {
+	b.state, b.probes, b.failures = s, 0, 0
+	b.gen++
+}
+
+func (b *circuitBreaker) currentState() CircuitState {
+	if b == nil {
+		return CircuitClosed
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advance()
+	return b.state
+}
+
+// breakerFailure reports whether err indicates an upstream failure.
+func breakerFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode >= 500
+	}
+	return true
+}
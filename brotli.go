@@ -0,0 +1,15 @@
+//go:build !carsxe_nobrotli
+
+package carsxe
+
+import (
+	"io"
+
+	"github.com/andybalholm/brotli"
+)
+
+// Brotli support is compiled in by default. Build with -tags carsxe_nobrotli
+// to drop it along with the github.com/andybalholm/brotli dependency.
+func init() {
+	decompressors["br"] = func(r io.Reader) (io.Reader, error) { return brotli.NewReader(r), nil }
+}
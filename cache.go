@@ -0,0 +1,169 @@
+package carsxe
+
+import (
+	"container/list"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultCacheTTL is how long responses are cached unless WithCacheTTL says
+// otherwise.
+const defaultCacheTTL = 24 * time.Hour
+
+// Cache stores raw response bodies. Implementations must be safe for
+// concurrent use.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, val []byte, ttl time.Duration)
+}
+
+// WithCache serves successful GET responses from c when possible and stores
+// fresh ones in it. POST requests are never cached.
+func WithCache(c Cache) Option {
+	return func(cl *Client) { cl.cache = c }
+}
+
+// WithCacheTTL sets how long cached responses stay valid (default 24h). A d
+// of zero or less stores them without an expiry, so they stay until the
+// cache evicts them; use WithEndpointCacheTTL to turn caching off for an
+// endpoint.
+func WithCacheTTL(d time.Duration) Option {
+	return func(c *Client) { c.cacheTTL = d }
+}
+
+// WithEndpointCacheTTL caches responses from endpoint (written as in the API
+// paths, e.g. "specs" or "v1/recalls") for d instead of the default TTL. A d
+// of zero or less disables caching for that endpoint.
+func WithEndpointCacheTTL(endpoint string, d time.Duration) Option {
+	return func(c *Client) {
+		if c.endpointTTLs == nil {
+			c.endpointTTLs = map[string]time.Duration{}
+		}
+		c.endpointTTLs[strings.Trim(endpoint, "/")] = d
+	}
+}
+
+// WithCacheBypass skips the cache lookup for a single call and stores the
+// fresh response, refreshing the cached entry.
+func WithCacheBypass() CallOption {
+	return func(co *callOptions) { co.refreshCache = true }
+}
+
+// cacheTTLFor returns the TTL for responses from endpoint, zero meaning no
+// expiry, and whether they are cached at all.
+func (c *Client) cacheTTLFor(endpoint string) (time.Duration, bool) {
+	if d, ok := c.endpointTTLs[strings.Trim(endpoint, "/")]; ok {
+		return d, d > 0
+	}
+	return max(c.cacheTTL, 0), true
+}
+
+// Forget removes the cached response for a GET of endpoint with params, if
+// the cache supports deletion (as LRUCache does).
+func (c *Client) Forget(endpoint string, params map[string]string) {
+	d, ok := c.cache.(interface{ Delete(key string) })
+	if !ok {
+		return
+	}
+	u, err := c.buildURL(endpoint, valuesOf(params))
+	if err != nil {
+		return
+	}
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return
+	}
+	d.Delete(cacheKeyFor(c.endpointPath(endpoint), parsed))
+}
+
+// cacheKeyFor derives the cache key for a request from its endpoint and
+// query string, leaving out the API key.
+func cacheKeyFor(endpoint string, u *url.URL) string {
+	q := u.Query()
+	q.Del("key")
+	return strings.TrimLeft(endpoint, "/") + "?" + q.Encode()
+}
+
+// LRUCache is an in-memory Cache holding up to a fixed number of entries,
+// evicting the least recently used one when full. Expired entries are
+// dropped when they are looked up.
+type LRUCache struct {
+	mu      sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   list.List // of *lruEntry, most recently used first
+}
+
+type lruEntry struct {
+	key     string
+	val     []byte
+	expires time.Time // zero means never
+}
+
+// NewLRUCache returns an LRUCache holding at most size entries (minimum 1).
+func NewLRUCache(size int) *LRUCache {
+	if size < 1 {
+		size = 1
+	}
+	return &LRUCache{size: size, entries: make(map[string]*list.Element)}
+}
+
+// Get returns the value stored for key if it has not expired.
+func (l *LRUCache) Get(key string) ([]byte, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	elem, ok := l.entries[key]
+	if !ok {
+		return nil, false
+	}
+	e := elem.Value.(*lruEntry)
+	if !e.expires.IsZero() && time.Now().After(e.expires) {
+		l.order.Remove(elem)
+		delete(l.entries, key)
+		return nil, false
+	}
+	l.order.MoveToFront(elem)
+	return e.val, true
+}
+
+// Set stores val for key for ttl; a ttl of zero or less never expires.
+func (l *LRUCache) Set(key string, val []byte, ttl time.Duration) {
+	var expires time.Time
+	if ttl > 0 {
+		expires = time.Now().Add(ttl)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if elem, ok := l.entries[key]; ok {
+		e := elem.Value.(*lruEntry)
+		e.val, e.expires = val, expires
+		l.order.MoveToFront(elem)
+		return
+	}
+	l.entries[key] = l.order.PushFront(&lruEntry{key: key, val: val, expires: expires})
+	for l.order.Len() > l.size {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.entries, oldest.Value.(*lruEntry).key)
+	}
+}
+
+// Delete removes the entry for key, if any.
+func (l *LRUCache) Delete(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if elem, ok := l.entries[key]; ok {
+		l.order.Remove(elem)
+		delete(l.entries, key)
+	}
+}
+
+// Len returns the number of entries currently stored, including expired
+// ones not yet looked up.
+func (l *LRUCache) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.order.Len()
+}
@@ -0,0 +1,96 @@
+// Package carsxetest provides helpers for testing code that uses the CarsXE
+// client without making real HTTP requests.
+package carsxetest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	carsxe "github.com/carsxe/carsxe-go-package"
+)
+
+// baseURL is the address mock clients send their requests to. It is never
+// dialled.
+const baseURL = "http://carsxe.test"
+
+// Handler answers a mocked API call. endpoint is the API path without a
+// leading slash (e.g. "specs", "v1/recalls") and params holds the query
+// parameters, without "key" and "source", merged with the fields of a JSON
+// request body such as {"image": "<url>"}.
+//
+// Returning an *carsxe.APIError makes the client receive a response with
+// its StatusCode and Body (or a JSON envelope built from Message and Code
+// when Body is empty); any other error is reported as a network failure.
+type Handler func(endpoint string, params map[string]string) (map[string]any, error)
+
+// NewMockClient returns a client whose calls are routed to handler instead
+// of the network. opts are applied after the mock transport is installed,
+// so most client options can be combined with it; WithHTTPClient and
+// WithBaseURL would bypass the mock.
+func NewMockClient(handler Handler, opts ...carsxe.Option) *carsxe.Client {
+	hc := &http.Client{Transport: carsxe.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return serve(handler, req)
+	})}
+	base := []carsxe.Option{carsxe.WithHTTPClient(hc), carsxe.WithBaseURL(baseURL)}
+	return carsxe.New("test-key", append(base, opts...)...)
+}
+
+// serve turns req into a Handler call and its outcome into a response.
+func serve(handler Handler, req *http.Request) (*http.Response, error) {
+	params := map[string]string{}
+	for k, v := range req.URL.Query() {
+		if k != "key" && k != "source" && len(v) > 0 {
+			params[k] = v[0]
+		}
+	}
+	if req.Body != nil && strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") {
+		var fields map[string]any
+		if err := json.NewDecoder(req.Body).Decode(&fields); err != nil && err != io.EOF {
+			return nil, err
+		}
+		for k, v := range fields {
+			if s, ok := v.(string); ok {
+				params[k] = s
+			} else if b, err := json.Marshal(v); err == nil {
+				params[k] = string(b)
+			}
+		}
+	}
+
+	status := http.StatusOK
+	var body []byte
+	out, err := handler(strings.TrimLeft(req.URL.Path, "/"), params)
+	var apiErr *carsxe.APIError
+	switch {
+	case errors.As(err, &apiErr):
+		status = apiErr.StatusCode
+		body = []byte(apiErr.Body)
+		if len(body) == 0 {
+			body, _ = json.Marshal(map[string]string{"message": apiErr.Message, "code": apiErr.Code})
+		}
+	case err != nil:
+		return nil, err
+	default:
+		if out == nil {
+			out = map[string]any{}
+		}
+		if body, err = json.Marshal(out); err != nil {
+			return nil, err
+		}
+	}
+	return &http.Response{
+		StatusCode:    status,
+		Status:        http.StatusText(status),
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,148 @@
+package carsxetest
+
+// Fixtures holds a representative successful response for every endpoint,
+// keyed by API path. NewServer serves them by default; override single
+// endpoints with MockServer.Fixture or MockServer.Handle. Treat the maps as
+// read-only.
+var Fixtures = map[string]map[string]any{
+	"specs": {
+		"success": true,
+		"input":   map[string]any{"vin": "WBAFR7C57CC811956"},
+		"attributes": map[string]any{
+			"year":                                "2012",
+			"make":                                "BMW",
+			"model":                               "5-Series",
+			"trim":                                "535i",
+			"style":                               "SEDAN 4-DR",
+			"type":                                "SEDAN",
+			"made_in":                             "GERMANY",
+			"doors":                               "4",
+			"body_style":                          "SEDAN 4-DR",
+			"city_mileage":                        "20 miles/gallon",
+			"highway_mileage":                     "30 miles/gallon",
+			"standard_seating":                    "5",
+			"curb_weight":                         "4056",
+			"manufacturer_suggested_retail_price": "$54,100 USD",
+		},
+		"colors": []any{
+			map[string]any{"category": "Exterior", "name": "Alpine White"},
+			map[string]any{"category": "Interior", "name": "Black Dakota Leather"},
+		},
+	},
+	"v2/marketvalue": {
+		"success":        true,
+		"vin":            "WBAFR7C57CC811956",
+		"state":          "CA",
+		"retail":         "14250",
+		"tradeIn":        "9875",
+		"roughTradeIn":   "8300",
+		"averageTradeIn": "9100",
+		"loanValue":      "8900",
+		"msrp":           "54100",
+		"mileage":        "98000",
+	},
+	"history": {
+		"success": true,
+		"vin":     "WBAFR7C57CC811956",
+		"titleRecords": []any{
+			map[string]any{"date": "2012-03-14", "state": "CA", "description": "Title issued"},
+			map[string]any{"date": "2018-07-02", "state": "NV", "description": "Title transferred"},
+		},
+	},
+	"v1/recalls": {
+		"success": true,
+		"data": map[string]any{
+			"vin":  "1C4JJXR64PW696340",
+			"make": "JEEP",
+			"recalls": []any{
+				map[string]any{
+					"recall_date":        "2023-04-20",
+					"nhtsa_id":           "23V292000",
+					"component":          "ELECTRICAL SYSTEM",
+					"recall_description": "The battery may fail internally.",
+					"recall_remedy":      "Dealers will replace the battery, free of charge.",
+					"recall_status":      "Open",
+					"affected_units":     "20000",
+				},
+			},
+		},
+	},
+	"v1/international-vin-decoder": {
+		"success": true,
+		"attributes": map[string]any{
+			"vin":          "WF0MXXGBWM8R43240",
+			"make":         "Ford",
+			"model":        "Galaxy",
+			"year":         "2008",
+			"manufacturer": "Ford-Werke GmbH",
+			"body":         "MPV",
+			"fuel_type":    "Diesel",
+			"engine_size":  "1997",
+			"transmission": "Manual",
+		},
+	},
+	"v2/platedecoder": {
+		"success":     true,
+		"vin":         "5TDBT48A31S029394",
+		"description": "TOYOTA SEQUOIA 2001",
+		"make":        "TOYOTA",
+		"model":       "SEQUOIA",
+		"year":        "2001",
+	},
+	"platerecognition": {
+		"success": true,
+		"results": []any{
+			map[string]any{
+				"plate":   "7ABC123",
+				"score":   0.91,
+				"dscore":  0.87,
+				"box":     map[string]any{"xmin": 143, "ymin": 481, "xmax": 282, "ymax": 540},
+				"region":  map[string]any{"code": "us-ca"},
+				"vehicle": map[string]any{"type": "Sedan"},
+				"candidates": []any{
+					map[string]any{"plate": "7ABC123", "score": 0.91},
+					map[string]any{"plate": "7A8C123", "score": 0.42},
+				},
+			},
+		},
+	},
+	"v1/vinocr": {
+		"success": true,
+		"data": map[string]any{
+			"vin":        "JHLRD68404C018253",
+			"confidence": 0.98,
+			"candidates": []any{map[string]any{"vin": "JHLRD68404C018253", "confidence": 0.98}},
+		},
+	},
+	"v1/ymm": {
+		"success": true,
+		"bestMatch": map[string]any{
+			"make": "Toyota", "model": "Camry", "trim": "LE", "description": "2023 Toyota Camry LE", "msrp": 26420,
+		},
+		"allTrims": []any{
+			map[string]any{"make": "Toyota", "model": "Camry", "trim": "LE", "msrp": 26420},
+			map[string]any{"make": "Toyota", "model": "Camry", "trim": "SE", "msrp": 28160},
+		},
+	},
+	"images": {
+		"success": true,
+		"images": []any{
+			map[string]any{"link": "https://example.com/camry.jpg", "thumbnailLink": "https://example.com/camry-thumb.jpg", "width": 1024, "height": 768},
+		},
+	},
+	"obdcodesdecoder": {
+		"success":   true,
+		"code":      "P0115",
+		"diagnosis": "Engine Coolant Temperature Circuit Malfunction",
+		"date":      "2024-01-01",
+	},
+	"v1/lien-theft": {
+		"success": true,
+		"data": map[string]any{
+			"vin": "2C3CDXFG1FH762860",
+			"events": []any{
+				map[string]any{"type": "theft", "date": "2019-05-11", "description": "Reported stolen"},
+			},
+		},
+	},
+}
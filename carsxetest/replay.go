@@ -0,0 +1,66 @@
+package carsxetest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	carsxe "github.com/carsxe/carsxe-go-package"
+)
+
+// Replay answers the endpoints in calls, as captured by carsxe.Recorder, with
+// their recorded responses. A request gets the response recorded with the
+// same parameters, or else the last one recorded for its endpoint.
+// Endpoints not in calls keep their current handler.
+func (s *MockServer) Replay(calls []carsxe.RecordedCall) {
+	byEndpoint := map[string][]carsxe.RecordedCall{}
+	for _, c := range calls {
+		endpoint := strings.Trim(c.Endpoint, "/")
+		byEndpoint[endpoint] = append(byEndpoint[endpoint], c)
+	}
+	for endpoint, recorded := range byEndpoint {
+		s.Handle(endpoint, replayHandler(recorded))
+	}
+}
+
+// ReplayFile is like Replay for a fixture file written by
+// carsxe.Recorder.Save.
+func (s *MockServer) ReplayFile(path string) error {
+	calls, err := carsxe.LoadRecording(path)
+	if err != nil {
+		return err
+	}
+	s.Replay(calls)
+	return nil
+}
+
+func replayHandler(recorded []carsxe.RecordedCall) Handler {
+	return func(_ string, params map[string]string) (map[string]any, error) {
+		match := recorded[len(recorded)-1]
+		for _, c := range recorded {
+			if sameParams(c.Params, params) {
+				match = c
+				break
+			}
+		}
+		if match.Status < 200 || match.Status > 299 {
+			body := string(match.Body)
+			var text string
+			if json.Unmarshal(match.Body, &text) == nil {
+				body = text
+			}
+			return nil, &carsxe.APIError{StatusCode: match.Status, Body: body}
+		}
+		var body map[string]any
+		if err := json.Unmarshal(match.Body, &body); err != nil {
+			return nil, &carsxe.APIError{StatusCode: http.StatusInternalServerError,
+				Message: "carsxetest: recorded body is not a JSON object"}
+		}
+		return body, nil
+	}
+}
+
+// sameParams reports whether a and b hold the same entries.
+func sameParams(a, b map[string]string) bool {
+	return len(a) == len(b) && hasParams(a, b)
+}
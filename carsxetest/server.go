@@ -0,0 +1,156 @@
+package carsxetest
+
+import (
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	carsxe "github.com/carsxe/carsxe-go-package"
+)
+
+// Request is a call received by a MockServer.
+type Request struct {
+	Method string
+	// Endpoint is the API path without a leading slash, e.g. "specs".
+	Endpoint string
+	// Params holds the query parameters, without "key" and "source", merged
+	// with the fields of a JSON request body.
+	Params map[string]string
+}
+
+// MockServer is an httptest server speaking the CarsXE API. It answers each
+// endpoint with its fixture (see Fixtures) or handler and records every
+// request for later assertions. Unknown endpoints get a 404.
+type MockServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	handlers map[string]Handler
+	requests []Request
+}
+
+// NewServer starts a MockServer serving Fixtures. Close it when done, e.g.
+// with t.Cleanup(s.Close).
+func NewServer() *MockServer {
+	s := &MockServer{handlers: map[string]Handler{}}
+	for endpoint, body := range Fixtures {
+		s.Fixture(endpoint, body)
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Client returns a client sending its requests to s, with opts applied.
+func (s *MockServer) Client(opts ...carsxe.Option) *carsxe.Client {
+	return carsxe.New("test-key", append([]carsxe.Option{carsxe.WithBaseURL(s.URL)}, opts...)...)
+}
+
+// Handle answers calls to endpoint (e.g. "specs" or "v1/recalls") with h.
+func (s *MockServer) Handle(endpoint string, h Handler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[strings.Trim(endpoint, "/")] = h
+}
+
+// Fixture answers every call to endpoint with body.
+func (s *MockServer) Fixture(endpoint string, body map[string]any) {
+	s.Handle(endpoint, func(string, map[string]string) (map[string]any, error) {
+		return body, nil
+	})
+}
+
+// Requests returns the requests received so far, oldest first.
+func (s *MockServer) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+// Reset forgets the recorded requests.
+func (s *MockServer) Reset() {
+	s.mu.Lock()
+	s.requests = nil
+	s.mu.Unlock()
+}
+
+// Calls returns the recorded requests to endpoint whose parameters include
+// params.
+func (s *MockServer) Calls(endpoint string, params map[string]string) []Request {
+	endpoint = strings.Trim(endpoint, "/")
+	var out []Request
+	for _, r := range s.Requests() {
+		if r.Endpoint == endpoint && hasParams(r.Params, params) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// AssertCalled fails t unless endpoint was called with parameters
+// including params.
+func (s *MockServer) AssertCalled(t testing.TB, endpoint string, params map[string]string) {
+	t.Helper()
+	if len(s.Calls(endpoint, params)) == 0 {
+		t.Errorf("carsxetest: no call to %s with %v; got %v", endpoint, params, s.Requests())
+	}
+}
+
+// AssertNotCalled fails t if endpoint was called with parameters including
+// params.
+func (s *MockServer) AssertNotCalled(t testing.TB, endpoint string, params map[string]string) {
+	t.Helper()
+	if calls := s.Calls(endpoint, params); len(calls) > 0 {
+		t.Errorf("carsxetest: unexpected call to %s with %v", endpoint, calls[0].Params)
+	}
+}
+
+// AssertCallCount fails t unless endpoint was called exactly n times.
+func (s *MockServer) AssertCallCount(t testing.TB, endpoint string, n int) {
+	t.Helper()
+	if got := len(s.Calls(endpoint, nil)); got != n {
+		t.Errorf("carsxetest: %s called %d times, want %d", endpoint, got, n)
+	}
+}
+
+func (s *MockServer) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	endpoint := strings.Trim(req.URL.Path, "/")
+	s.mu.Lock()
+	h := s.handlers[endpoint]
+	s.mu.Unlock()
+	if h == nil {
+		h = func(string, map[string]string) (map[string]any, error) {
+			return nil, &carsxe.APIError{StatusCode: http.StatusNotFound, Message: "carsxetest: no fixture for " + endpoint}
+		}
+	}
+
+	record := func(_ string, params map[string]string) (map[string]any, error) {
+		s.mu.Lock()
+		s.requests = append(s.requests, Request{Method: req.Method, Endpoint: endpoint, Params: maps.Clone(params)})
+		s.mu.Unlock()
+		return h(endpoint, params)
+	}
+	resp, err := serve(record, req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer resp.Body.Close()
+	maps.Copy(w.Header(), resp.Header)
+	w.WriteHeader(resp.StatusCode)
+	io.Copy(w, resp.Body)
+}
+
+// hasParams reports whether got includes every entry of want.
+func hasParams(got, want map[string]string) bool {
+	for k, v := range want {
+		if got[k] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,56 @@
+package carsxe
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrChecksumMismatch is returned when a response body does not match the
+// checksum header sent with it.
+var ErrChecksumMismatch = errors.New("carsxe: response checksum mismatch")
+
+// WithVerifyChecksums verifies response bodies against a Content-MD5 or
+// X-Content-SHA256 header (hex or base64 encoded) and fails the call with
+// ErrChecksumMismatch when they differ. Responses without either header, or
+// that were transparently decompressed by the transport, are accepted as-is.
+func WithVerifyChecksums() Option {
+	return func(c *Client) { c.verifyChecksums = true }
+}
+
+// verifyChecksum checks body against the checksum headers of resp.
+func verifyChecksum(resp *http.Response, body []byte) error {
+	if resp.Uncompressed {
+		return nil
+	}
+	if v := strings.TrimSpace(resp.Header.Get("X-Content-SHA256")); v != "" {
+		sum := sha256.Sum256(body)
+		if !digestMatches(v, sum[:]) {
+			return fmt.Errorf("%w: X-Content-SHA256", ErrChecksumMismatch)
+		}
+	}
+	if v := strings.TrimSpace(resp.Header.Get("Content-MD5")); v != "" {
+		sum := md5.Sum(body)
+		if !digestMatches(v, sum[:]) {
+			return fmt.Errorf("%w: Content-MD5", ErrChecksumMismatch)
+		}
+	}
+	return nil
+}
+
+// digestMatches compares a hex or base64 encoded header value with sum.
+func digestMatches(header string, sum []byte) bool {
+	if b, err := hex.DecodeString(header); err == nil && len(b) == len(sum) {
+		return bytes.Equal(b, sum)
+	}
+	if b, err := base64.StdEncoding.DecodeString(header); err == nil {
+		return bytes.Equal(b, sum)
+	}
+	return false
+}
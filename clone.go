@@ -0,0 +1,134 @@
+package carsxe
+
+import (
+	"crypto/tls"
+	"maps"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// Clone returns a new client with c's configuration and opts applied on top,
+// for per-tenant or per-operation variations such as a different source or
+// timeout. It is safe to call concurrently with requests on c.
+//
+// The clone shares c's connection pool unless opts change the transport
+// (WithHTTPClient, WithTransport, WithRoundTripper, WithDialRetry,
+// WithResponseHeaderTimeout, WithProxy or WithTLSConfig). State is handled
+// as follows:
+//
+//   - the cache, logger, metrics, rate limiter, concurrency and upload
+//     limits, circuit breaker, WithAPIKeys pool, in-flight calls of
+//     WithSingleflight, background refreshes of WithStaleWhileRevalidate,
+//     the WithSerialized queue and the credits counted by Usage are shared,
+//     so the clone counts against the same budgets as c; options that
+//     configure them give the clone its own fresh instance instead;
+//   - WithMetrics, WithRoundTripper, WithInterceptor, WithAllowedEndpoints,
+//     WithEndpointOverride, WithAPIVersion, WithHeaders and the request and
+//     response hooks add to the inherited values without affecting c;
+//   - LastError, LastUsage and RateLimitState start empty.
+func (c *Client) Clone(opts ...Option) *Client {
+	cp := &Client{
+		apiKey:     c.apiKey,
+		baseURL:    c.baseURL,
+		source:     c.source,
+		httpClient: c.baseHTTP,
+		logger:     c.logger,
+		logLevels:  c.logLevels,
+		logBodies:  c.logBodies,
+
+		uploadBudget:   c.uploadBudget,
+		maxUploadSize:  c.maxUploadSize,
+		maxRespBytes:   c.maxRespBytes,
+		maxJSONDepth:   c.maxJSONDepth,
+		errBodyLimit:   c.errBodyLimit,
+		limiter:        c.limiter,
+		limiterGate:    c.limiterGate,
+		concurrency:    c.concurrency,
+		cache:          c.cache,
+		cacheTTL:       c.cacheTTL,
+		endpointTTLs:   maps.Clone(c.endpointTTLs),
+		staleWindow:    c.staleWindow,
+		endpointStale:  maps.Clone(c.endpointStale),
+		revalidating:   c.revalidating,
+		metrics:        slices.Clone(c.metrics),
+		middleware:     slices.Clone(c.middleware),
+		vinRedactor:    c.vinRedactor,
+		serial:         c.serial,
+		enumDecoding:   c.enumDecoding,
+		units:          c.units,
+		lenient:        c.lenient,
+		useNumber:      c.useNumber,
+		strictDecoding: c.strictDecoding,
+		validateVINs:   c.validateVINs,
+		validateParams: c.validateParams,
+		unwrapEnvelope: c.unwrapEnvelope,
+		offlineOBD:     c.offlineOBD,
+		dryRun:         c.dryRun,
+		flights:        c.flights,
+		keys:           c.keys,
+		auth:           c.auth,
+		auditSink:      c.auditSink,
+		credits:        c.credits,
+		allowed:        maps.Clone(c.allowed),
+		routes:         maps.Clone(c.routes),
+		apiVersions:    maps.Clone(c.apiVersions),
+		breaker:        c.breaker,
+
+		maxRetries:     c.maxRetries,
+		retryBaseDelay: c.retryBaseDelay,
+		retryPolicy:    c.retryPolicy,
+		hedgeDelay:     c.hedgeDelay,
+		maxHedges:      c.maxHedges,
+
+		headers:       c.headers.Clone(),
+		userAgent:     c.userAgent,
+		interceptors:  slices.Clone(c.interceptors),
+		requestHooks:  slices.Clone(c.requestHooks),
+		responseHooks: slices.Clone(c.responseHooks),
+
+		dialRetries:           c.dialRetries,
+		dialDelay:             c.dialDelay,
+		responseHeaderTimeout: c.responseHeaderTimeout,
+		proxySet:              c.proxySet,
+		proxyURL:              c.proxyURL,
+		tlsConfig:             c.tlsConfig,
+		bodyReadTimeout:       c.bodyReadTimeout,
+		verifyChecksums:       c.verifyChecksums,
+	}
+	for _, o := range opts {
+		o(cp)
+	}
+	cp.baseHTTP = cp.httpClient
+
+	if cp.transportSettings() != c.transportSettings() || cp.httpClient.Transport != c.baseHTTP.Transport {
+		cp.buildTransport()
+		return cp
+	}
+	// Same transport settings: reuse c's built transport, keeping any other
+	// http.Client changes such as WithTimeout.
+	if cp.httpClient != c.baseHTTP {
+		hc := *cp.httpClient
+		hc.Transport = c.httpClient.Transport
+		cp.httpClient = &hc
+	} else {
+		cp.httpClient = c.httpClient
+	}
+	return cp
+}
+
+// transportSettings captures the options that buildTransport depends on.
+type transportSettings struct {
+	dialRetries           int
+	dialDelay             time.Duration
+	responseHeaderTimeout time.Duration
+	middleware            int
+	proxySet              bool
+	proxyURL              *url.URL
+	tlsConfig             *tls.Config
+}
+
+func (c *Client) transportSettings() transportSettings {
+	return transportSettings{c.dialRetries, c.dialDelay, c.responseHeaderTimeout, len(c.middleware),
+		c.proxySet, c.proxyURL, c.tlsConfig}
+}
@@ -0,0 +1,304 @@
+// Command carsxe queries the CarsXE API from the command line.
+//
+// Usage:
+//
+//	carsxe [-o json|table] [-timeout 30s] <command> [flags]
+//
+// Commands:
+//
+//	specs        -vin VIN [-deepdata]
+//	marketvalue  -vin VIN [-state ST] [-mileage N]
+//	history      -vin VIN
+//	recalls      -vin VIN
+//	intvin       -vin VIN
+//	plate        -plate PLATE [-country CC] [-state ST]
+//	vinocr       -image PATH|URL
+//	platerecog   -image PATH|URL
+//	ymm          -year Y -make MAKE -model MODEL [-trim TRIM]
+//	images       -make MAKE -model MODEL [-year Y] [-color C]
+//	obd          -code CODE
+//	lientheft    -vin VIN
+//
+// The API key is read from CARSXE_API_KEY, along with the other variables
+// understood by carsxe.NewFromEnv. The exit status is 0 on success, 1 for
+// API and network errors, 2 for usage errors, 3 when the API key is rejected
+// and 4 when the API has no data for the query.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	carsxe "github.com/carsxe/carsxe-go-package"
+)
+
+const (
+	exitOK = iota
+	exitError
+	exitUsage
+	exitUnauthorized
+	exitNotFound
+)
+
+// command runs one subcommand with its flags parsed.
+type command struct {
+	usage string
+	flags func(fs *flag.FlagSet) func(ctx context.Context, c *carsxe.Client) (map[string]any, error)
+}
+
+var commands = map[string]command{
+	"specs": {"-vin VIN [-deepdata]", func(fs *flag.FlagSet) func(context.Context, *carsxe.Client) (map[string]any, error) {
+		vin := fs.String("vin", "", "vehicle identification number")
+		deep := fs.Bool("deepdata", false, "include deep data")
+		return func(ctx context.Context, c *carsxe.Client) (map[string]any, error) {
+			p := map[string]string{"vin": *vin}
+			if *deep {
+				p["deepdata"] = "1"
+			}
+			return c.SpecsContext(ctx, p)
+		}
+	}},
+	"marketvalue": {"-vin VIN [-state ST] [-mileage N]", func(fs *flag.FlagSet) func(context.Context, *carsxe.Client) (map[string]any, error) {
+		vin := fs.String("vin", "", "vehicle identification number")
+		state := fs.String("state", "", "US state for regional pricing")
+		mileage := fs.String("mileage", "", "odometer reading")
+		return func(ctx context.Context, c *carsxe.Client) (map[string]any, error) {
+			p := map[string]string{"vin": *vin}
+			if *state != "" {
+				p["state"] = *state
+			}
+			if *mileage != "" {
+				p["mileage"] = *mileage
+			}
+			return c.MarketValueContext(ctx, p)
+		}
+	}},
+	"history":   vinCommand((*carsxe.Client).HistoryContext),
+	"recalls":   vinCommand((*carsxe.Client).RecallsContext),
+	"intvin":    vinCommand((*carsxe.Client).InternationalVINDecoderContext),
+	"lientheft": vinCommand((*carsxe.Client).LienAndTheftContext),
+	"plate": {"-plate PLATE [-country CC] [-state ST]", func(fs *flag.FlagSet) func(context.Context, *carsxe.Client) (map[string]any, error) {
+		plate := fs.String("plate", "", "license plate")
+		country := fs.String("country", "US", "ISO 3166-1 alpha-2 country code")
+		state := fs.String("state", "", "state or province")
+		return func(ctx context.Context, c *carsxe.Client) (map[string]any, error) {
+			if err := carsxe.ValidateRegion(*country, *state); err != nil {
+				return nil, usageError{err}
+			}
+			p := map[string]string{"plate": *plate, "country": *country}
+			if *state != "" {
+				p["state"] = *state
+			}
+			return c.PlateDecoderContext(ctx, p)
+		}
+	}},
+	"vinocr": imageCommand((*carsxe.Client).VinOCRContext, (*carsxe.Client).VinOCRFromFile),
+	"platerecog": imageCommand((*carsxe.Client).PlateImageRecognitionContext,
+		(*carsxe.Client).PlateImageRecognitionFromFile),
+	"ymm": {"-year Y -make MAKE -model MODEL [-trim TRIM]", func(fs *flag.FlagSet) func(context.Context, *carsxe.Client) (map[string]any, error) {
+		p := paramFlags(fs, "year", "make", "model", "trim")
+		return func(ctx context.Context, c *carsxe.Client) (map[string]any, error) {
+			return c.YearMakeModelContext(ctx, p())
+		}
+	}},
+	"images": {"-make MAKE -model MODEL [-year Y] [-color C]", func(fs *flag.FlagSet) func(context.Context, *carsxe.Client) (map[string]any, error) {
+		p := paramFlags(fs, "make", "model", "year", "color")
+		return func(ctx context.Context, c *carsxe.Client) (map[string]any, error) {
+			return c.ImagesContext(ctx, p())
+		}
+	}},
+	"obd": {"-code CODE", func(fs *flag.FlagSet) func(context.Context, *carsxe.Client) (map[string]any, error) {
+		code := fs.String("code", "", "diagnostic trouble code, e.g. P0115")
+		return func(ctx context.Context, c *carsxe.Client) (map[string]any, error) {
+			return c.ObdCodesDecoderContext(ctx, map[string]string{"code": *code})
+		}
+	}},
+}
+
+type endpointFunc func(c *carsxe.Client, ctx context.Context, params map[string]string, opts ...carsxe.CallOption) (map[string]any, error)
+
+// vinCommand builds a command taking only a VIN.
+func vinCommand(fn endpointFunc) command {
+	return command{"-vin VIN", func(fs *flag.FlagSet) func(context.Context, *carsxe.Client) (map[string]any, error) {
+		vin := fs.String("vin", "", "vehicle identification number")
+		return func(ctx context.Context, c *carsxe.Client) (map[string]any, error) {
+			return fn(c, ctx, map[string]string{"vin": *vin})
+		}
+	}}
+}
+
+// imageCommand builds a command taking an image URL or a local file.
+func imageCommand(byURL func(*carsxe.Client, context.Context, string, ...carsxe.CallOption) (map[string]any, error),
+	byFile func(*carsxe.Client, context.Context, string, ...carsxe.CallOption) (map[string]any, error)) command {
+	return command{"-image PATH|URL", func(fs *flag.FlagSet) func(context.Context, *carsxe.Client) (map[string]any, error) {
+		image := fs.String("image", "", "image URL or local file")
+		return func(ctx context.Context, c *carsxe.Client) (map[string]any, error) {
+			if strings.HasPrefix(*image, "http://") || strings.HasPrefix(*image, "https://") {
+				return byURL(c, ctx, *image)
+			}
+			return byFile(c, ctx, *image)
+		}
+	}}
+}
+
+// paramFlags defines a string flag per name and returns a function
+// collecting the ones set to a non-empty value into params.
+func paramFlags(fs *flag.FlagSet, names ...string) func() map[string]string {
+	values := make([]*string, len(names))
+	for i, name := range names {
+		values[i] = fs.String(name, "", name)
+	}
+	return func() map[string]string {
+		p := make(map[string]string, len(names))
+		for i, name := range names {
+			if *values[i] != "" {
+				p[name] = *values[i]
+			}
+		}
+		return p
+	}
+}
+
+// usageError marks errors in the command line.
+type usageError struct{ err error }
+
+func (e usageError) Error() string { return e.err.Error() }
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("carsxe", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	output := fs.String("o", "json", "output format: json or table")
+	timeout := fs.Duration("timeout", 30*time.Second, "overall timeout")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: carsxe [-o json|table] [-timeout d] <command> [flags]")
+		fmt.Fprintln(stderr, "\ncommands:")
+		names := make([]string, 0, len(commands))
+		for name := range commands {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(stderr, "  %-12s %s\n", name, commands[name].usage)
+		}
+	}
+	if err := fs.Parse(args); err != nil {
+		return exitUsage
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return exitUsage
+	}
+	if *output != "json" && *output != "table" {
+		fmt.Fprintf(stderr, "carsxe: unknown output format %q\n", *output)
+		return exitUsage
+	}
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		fmt.Fprintf(stderr, "carsxe: unknown command %q\n", fs.Arg(0))
+		fs.Usage()
+		return exitUsage
+	}
+	sub := flag.NewFlagSet("carsxe "+fs.Arg(0), flag.ContinueOnError)
+	sub.SetOutput(stderr)
+	call := cmd.flags(sub)
+	if err := sub.Parse(fs.Args()[1:]); err != nil {
+		return exitUsage
+	}
+
+	client, err := carsxe.NewFromEnv(carsxe.WithParamValidation())
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitUsage
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+
+	resp, err := call(ctx, client)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitCode(err)
+	}
+	if *output == "table" {
+		err = writeTable(stdout, resp)
+	} else {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(resp)
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return exitError
+	}
+	return exitOK
+}
+
+// exitCode maps err to the command's exit status.
+func exitCode(err error) int {
+	var missing *carsxe.ErrMissingParam
+	var usage usageError
+	switch {
+	case errors.As(err, &missing), errors.As(err, &usage), errors.Is(err, carsxe.ErrInvalidVIN):
+		return exitUsage
+	case carsxe.IsUnauthorized(err):
+		return exitUnauthorized
+	case carsxe.IsNotFound(err):
+		return exitNotFound
+	}
+	return exitError
+}
+
+// writeTable prints resp as aligned key/value rows, flattening nested
+// objects and arrays into dotted keys.
+func writeTable(w io.Writer, resp map[string]any) error {
+	rows := map[string]string{}
+	flatten("", resp, rows)
+	keys := make([]string, 0, len(rows))
+	for k := range rows {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, k := range keys {
+		fmt.Fprintf(tw, "%s\t%s\n", k, rows[k])
+	}
+	return tw.Flush()
+}
+
+func flatten(prefix string, v any, rows map[string]string) {
+	join := func(k string) string {
+		if prefix == "" {
+			return k
+		}
+		return prefix + "." + k
+	}
+	switch v := v.(type) {
+	case map[string]any:
+		for k, item := range v {
+			flatten(join(k), item, rows)
+		}
+	case []any:
+		for i, item := range v {
+			flatten(join(fmt.Sprint(i)), item, rows)
+		}
+	case nil:
+		rows[prefix] = ""
+	default:
+		rows[prefix] = fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,50 @@
+package carsxe
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// decompressors maps Content-Encoding tokens to body decoders. gzip is
+// always available; brotli ("br") is registered by brotli.go unless the
+// package is built with the carsxe_nobrotli tag.
+var decompressors = map[string]func(io.Reader) (io.Reader, error){
+	"gzip": func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) },
+}
+
+// acceptEncoding is the Accept-Encoding header the client sends. It is empty
+// when only gzip is supported, in which case net/http negotiates and
+// decompresses gzip transparently on its own.
+func acceptEncoding() string {
+	if len(decompressors) <= 1 {
+		return ""
+	}
+	names := make([]string, 0, len(decompressors))
+	for name := range decompressors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// responseBody returns a reader over the decoded body of resp and whether
+// the client decompressed it itself.
+func responseBody(resp *http.Response) (io.Reader, bool, error) {
+	enc := strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding")))
+	if enc == "" || enc == "identity" || resp.Uncompressed {
+		return resp.Body, false, nil
+	}
+	dec, ok := decompressors[enc]
+	if !ok {
+		return resp.Body, false, nil
+	}
+	r, err := dec(resp.Body)
+	if err != nil {
+		return nil, false, fmt.Errorf("Failed to decompress %s response: %w", enc, err)
+	}
+	return r, true, nil
+}
@@ -0,0 +1,206 @@
+package carsxe
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Duration is a time.Duration that is read from and written to config files
+// in time.ParseDuration format, e.g. "15s" or "1m30s".
+type Duration time.Duration
+
+// MarshalText implements encoding.TextMarshaler.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (d *Duration) UnmarshalText(b []byte) error {
+	v, err := time.ParseDuration(string(b))
+	if err != nil {
+		return err
+	}
+	*d = Duration(v)
+	return nil
+}
+
+// RateLimitConfig mirrors WithRateLimit.
+type RateLimitConfig struct {
+	RPS   float64 `json:"rps" yaml:"rps"`
+	Burst int     `json:"burst" yaml:"burst"`
+}
+
+// Config declares a client's settings, typically loaded from a JSON or YAML
+// file. Zero values keep the corresponding default.
+type Config struct {
+	APIKey  string   `json:"api_key" yaml:"api_key"`
+	BaseURL string   `json:"base_url,omitempty" yaml:"base_url,omitempty"`
+	Source  string   `json:"source,omitempty" yaml:"source,omitempty"`
+	Timeout Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+
+	RateLimit              *RateLimitConfig `json:"rate_limit,omitempty" yaml:"rate_limit,omitempty"`
+	MaxConcurrency         int              `json:"max_concurrency,omitempty" yaml:"max_concurrency,omitempty"`
+	MaxInflightUploadBytes int64            `json:"max_inflight_upload_bytes,omitempty" yaml:"max_inflight_upload_bytes,omitempty"`
+	MaxJSONDepth           int              `json:"max_json_depth,omitempty" yaml:"max_json_depth,omitempty"`
+	MaxResponseBytes       int64            `json:"max_response_bytes,omitempty" yaml:"max_response_bytes,omitempty"`
+	CacheTTL               Duration         `json:"cache_ttl,omitempty" yaml:"cache_ttl,omitempty"`
+	// MaxRetries and RetryBaseDelay enable WithRetry when MaxRetries is
+	// positive.
+	MaxRetries     int      `json:"max_retries,omitempty" yaml:"max_retries,omitempty"`
+	RetryBaseDelay Duration `json:"retry_base_delay,omitempty" yaml:"retry_base_delay,omitempty"`
+	// RedactVINs enables WithVINRedaction(HashRedactor).
+	RedactVINs bool `json:"redact_vins,omitempty" yaml:"redact_vins,omitempty"`
+	// APIVersion enables WithAPIVersion for every endpoint.
+	APIVersion string `json:"api_version,omitempty" yaml:"api_version,omitempty"`
+	// Endpoints maps endpoints to the paths or URLs passed to
+	// WithEndpointOverride.
+	Endpoints map[string]string `json:"endpoints,omitempty" yaml:"endpoints,omitempty"`
+}
+
+// Validate reports every problem with cfg at once.
+func (cfg Config) Validate() error {
+	var errs []error
+	if cfg.APIKey == "" {
+		errs = append(errs, errors.New("api_key is required"))
+	}
+	if cfg.BaseURL != "" {
+		u, err := url.Parse(cfg.BaseURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("base_url %q must be an absolute http(s) URL", cfg.BaseURL))
+		}
+	}
+	if cfg.Timeout < 0 {
+		errs = append(errs, errors.New("timeout must not be negative"))
+	}
+	if rl := cfg.RateLimit; rl != nil && (rl.RPS <= 0 || rl.Burst < 0) {
+		errs = append(errs, errors.New("rate_limit.rps must be positive and rate_limit.burst must not be negative"))
+	}
+	if cfg.MaxConcurrency < 0 {
+		errs = append(errs, errors.New("max_concurrency must not be negative"))
+	}
+	if cfg.MaxInflightUploadBytes < 0 {
+		errs = append(errs, errors.New("max_inflight_upload_bytes must not be negative"))
+	}
+	if cfg.MaxJSONDepth < 0 {
+		errs = append(errs, errors.New("max_json_depth must not be negative"))
+	}
+	if cfg.MaxResponseBytes < 0 {
+		errs = append(errs, errors.New("max_response_bytes must not be negative"))
+	}
+	if cfg.CacheTTL < 0 {
+		errs = append(errs, errors.New("cache_ttl must not be negative"))
+	}
+	if cfg.MaxRetries < 0 {
+		errs = append(errs, errors.New("max_retries must not be negative"))
+	}
+	if cfg.RetryBaseDelay < 0 {
+		errs = append(errs, errors.New("retry_base_delay must not be negative"))
+	}
+	for _, e := range slices.Sorted(maps.Keys(cfg.Endpoints)) {
+		if strings.Trim(cfg.Endpoints[e], "/") == "" {
+			errs = append(errs, fmt.Errorf("endpoints.%s must not be empty", e))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("carsxe: invalid config: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// Options converts cfg into the equivalent list of Options.
+func (cfg Config) Options() []Option {
+	var opts []Option
+	if cfg.BaseURL != "" {
+		opts = append(opts, WithBaseURL(cfg.BaseURL))
+	}
+	if cfg.Source != "" {
+		opts = append(opts, WithSource(cfg.Source))
+	}
+	if cfg.Timeout > 0 {
+		opts = append(opts, WithTimeout(time.Duration(cfg.Timeout)))
+	}
+	if cfg.RateLimit != nil {
+		opts = append(opts, WithRateLimit(cfg.RateLimit.RPS, cfg.RateLimit.Burst))
+	}
+	if cfg.MaxConcurrency > 0 {
+		opts = append(opts, WithMaxConcurrency(cfg.MaxConcurrency))
+	}
+	if cfg.MaxInflightUploadBytes > 0 {
+		opts = append(opts, WithMaxInflightUploadBytes(cfg.MaxInflightUploadBytes))
+	}
+	if cfg.MaxJSONDepth > 0 {
+		opts = append(opts, WithMaxJSONDepth(cfg.MaxJSONDepth))
+	}
+	if cfg.MaxResponseBytes > 0 {
+		opts = append(opts, WithMaxResponseBytes(cfg.MaxResponseBytes))
+	}
+	if cfg.CacheTTL > 0 {
+		opts = append(opts, WithCacheTTL(time.Duration(cfg.CacheTTL)))
+	}
+	if cfg.MaxRetries > 0 {
+		opts = append(opts, WithRetry(cfg.MaxRetries, time.Duration(cfg.RetryBaseDelay)))
+	}
+	if cfg.RedactVINs {
+		opts = append(opts, WithVINRedaction(HashRedactor))
+	}
+	if cfg.APIVersion != "" {
+		opts = append(opts, WithAPIVersion(cfg.APIVersion))
+	}
+	for e, p := range cfg.Endpoints {
+		opts = append(opts, WithEndpointOverride(e, p))
+	}
+	return opts
+}
+
+// NewFromConfig validates cfg and builds a client from it. Additional opts
+// are applied after the config, so they can supply things a config file
+// cannot express, such as a Cache or a logger.
+func NewFromConfig(cfg Config, opts ...Option) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return New(cfg.APIKey, append(cfg.Options(), opts...)...), nil
+}
+
+// Environment variables read by ConfigFromEnv.
+const (
+	EnvAPIKey  = "CARSXE_API_KEY"
+	EnvBaseURL = "CARSXE_BASE_URL"
+	EnvSource  = "CARSXE_SOURCE"
+	EnvTimeout = "CARSXE_TIMEOUT"
+)
+
+// ConfigFromEnv builds a Config from the CARSXE_API_KEY, CARSXE_BASE_URL,
+// CARSXE_SOURCE and CARSXE_TIMEOUT environment variables. The timeout is in
+// time.ParseDuration format, e.g. "30s". Unset variables keep the defaults;
+// the result is not validated.
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{
+		APIKey:  os.Getenv(EnvAPIKey),
+		BaseURL: os.Getenv(EnvBaseURL),
+		Source:  os.Getenv(EnvSource),
+	}
+	if v := os.Getenv(EnvTimeout); v != "" {
+		if err := cfg.Timeout.UnmarshalText([]byte(v)); err != nil {
+			return Config{}, fmt.Errorf("carsxe: invalid %s: %w", EnvTimeout, err)
+		}
+	}
+	return cfg, nil
+}
+
+// NewFromEnv builds a client from ConfigFromEnv, failing when CARSXE_API_KEY
+// is not set or a variable is invalid. Additional opts are applied after the
+// environment's settings.
+func NewFromEnv(opts ...Option) (*Client, error) {
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return NewFromConfig(cfg, opts...)
+}
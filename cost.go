@@ -0,0 +1,143 @@
+package carsxe
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+)
+
+// ErrBudgetExceeded is returned instead of making a call that would take the
+// credits consumed past the limit set by WithBudget.
+var ErrBudgetExceeded = errors.New("carsxe: credit budget exceeded")
+
+// CostModel maps endpoints (written as in the API paths, e.g. "specs" or
+// "v1/recalls") to the credits a successful call consumes. Endpoints not
+// listed cost 1 credit.
+type CostModel map[string]float64
+
+// WithCostModel sets the credit cost of endpoints, used by Usage and
+// WithBudget. Calling it again adds to the model.
+func WithCostModel(m CostModel) Option {
+	return func(c *Client) {
+		c.credits = c.credits.clone()
+		for endpoint, cost := range m {
+			c.credits.model[strings.Trim(endpoint, "/")] = cost
+		}
+	}
+}
+
+// WithBudget makes calls fail with ErrBudgetExceeded once the credits
+// consumed by the client, as counted by Usage, would exceed maxCredits.
+// Credits are reserved before each request is sent, including every copy
+// sent by WithHedging, so concurrent calls cannot overshoot. They are kept
+// for 2xx responses and for hedged copies cancelled because another copy
+// answered first, which the API may still bill. Cached responses are free.
+// A maxCredits of zero or less removes the limit.
+func WithBudget(maxCredits float64) Option {
+	return func(c *Client) {
+		c.credits = c.credits.clone()
+		c.credits.budget = max(maxCredits, 0)
+	}
+}
+
+// CreditUsage is the credit consumption counted by a client.
+type CreditUsage struct {
+	// Credits is the total consumed, per the client's CostModel.
+	Credits float64
+	// Calls is the number of successful calls made over the network,
+	// including cancelled hedged copies.
+	Calls int
+	// ByEndpoint breaks Credits down by endpoint.
+	ByEndpoint map[string]float64
+	// Budget is the limit set by WithBudget, 0 if none.
+	Budget float64
+}
+
+// Remaining returns the credits left in the budget, or -1 without one.
+func (u CreditUsage) Remaining() float64 {
+	if u.Budget <= 0 {
+		return -1
+	}
+	return max(u.Budget-u.Credits, 0)
+}
+
+// Usage returns the credits consumed by successful network calls since the
+// client was created, priced with its CostModel. Clones share the count
+// unless given their own WithCostModel or WithBudget. Unlike LastUsage it
+// does not depend on the API's usage headers.
+func (c *Client) Usage() CreditUsage {
+	return c.credits.usage()
+}
+
+// EstimateCost returns the credits a successful call to endpoint consumes
+// under the client's CostModel.
+func (c *Client) EstimateCost(endpoint string) float64 {
+	return c.credits.cost(endpoint)
+}
+
+// creditMeter counts consumed credits and enforces the budget.
+type creditMeter struct {
+	mu         sync.Mutex
+	model      CostModel
+	budget     float64
+	used       float64
+	reserved   float64
+	calls      int
+	byEndpoint map[string]float64
+}
+
+func newCreditMeter() *creditMeter {
+	return &creditMeter{model: CostModel{}, byEndpoint: map[string]float64{}}
+}
+
+// clone returns a fresh meter with m's settings and no usage.
+func (m *creditMeter) clone() *creditMeter {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp := newCreditMeter()
+	cp.model = maps.Clone(m.model)
+	cp.budget = m.budget
+	return cp
+}
+
+func (m *creditMeter) cost(endpoint string) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if cost, ok := m.model[strings.Trim(endpoint, "/")]; ok {
+		return cost
+	}
+	return 1
+}
+
+// reserve sets aside the cost of a call to endpoint, failing with
+// ErrBudgetExceeded if the budget does not allow it. The returned function
+// settles the reservation, keeping it for successful calls.
+func (m *creditMeter) reserve(endpoint string) (settle func(success bool), err error) {
+	endpoint = strings.Trim(endpoint, "/")
+	cost := m.cost(endpoint)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.budget > 0 && m.used+m.reserved+cost > m.budget {
+		return nil, fmt.Errorf("%w: %s costs %g credits, %g of %g used",
+			ErrBudgetExceeded, endpoint, cost, m.used+m.reserved, m.budget)
+	}
+	m.reserved += cost
+	return func(success bool) {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.reserved -= cost
+		if success {
+			m.used += cost
+			m.calls++
+			m.byEndpoint[endpoint] += cost
+		}
+	}, nil
+}
+
+func (m *creditMeter) usage() CreditUsage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return CreditUsage{Credits: m.used, Calls: m.calls, ByEndpoint: maps.Clone(m.byEndpoint), Budget: m.budget}
+}
@@ -0,0 +1,359 @@
+package carsxe
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"mime"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// toFloat converts JSON numbers and numeric strings to float64. Strings may
+// carry thousands separators and a unit suffix or prefix ("3.0L", "17 mpg",
+// "4,000 lbs", "$25,000"); the first number found is used.
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case int:
+		return float64(n), true
+	case string:
+		return parseLeadingNumber(n)
+	}
+	return 0, false
+}
+
+// toInt is toFloat truncated to an int.
+func toInt(v any) (int, bool) {
+	if n, ok := v.(json.Number); ok {
+		if i, err := n.Int64(); err == nil {
+			return int(i), true
+		}
+	}
+	f, ok := toFloat(v)
+	return int(f), ok
+}
+
+// toString converts scalar JSON values to their string form.
+func toString(v any) string {
+	switch s := v.(type) {
+	case string:
+		return strings.TrimSpace(s)
+	case float64:
+		return strconv.FormatFloat(s, 'f', -1, 64)
+	case json.Number:
+		return s.String()
+	case bool:
+		return strconv.FormatBool(s)
+	}
+	return ""
+}
+
+// parseLeadingNumber extracts the first decimal number from s.
+func parseLeadingNumber(s string) (float64, bool) {
+	s = strings.ReplaceAll(s, ",", "")
+	start := -1
+	for i, r := range s {
+		if r >= '0' && r <= '9' {
+			start = i
+			break
+		}
+	}
+	if start < 0 {
+		return 0, false
+	}
+	if start > 0 && s[start-1] == '-' {
+		start--
+	}
+	if start > 0 && s[start-1] == '.' {
+		start--
+	}
+	end := start + 1
+	seenDot := s[start] == '.'
+	for end < len(s) {
+		c := s[end]
+		if c == '.' && !seenDot {
+			seenDot = true
+		} else if c < '0' || c > '9' {
+			break
+		}
+		end++
+	}
+	f, err := strconv.ParseFloat(strings.TrimSuffix(s[start:end], "."), 64)
+	return f, err == nil
+}
+
+// asMap returns v as a JSON object, or nil.
+func asMap(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	return m
+}
+
+// asSlice returns v as a JSON array, or nil.
+func asSlice(v any) []any {
+	s, _ := v.([]any)
+	return s
+}
+
+// defaultMaxJSONDepth is the nesting limit applied unless WithMaxJSONDepth
+// overrides it. Real CarsXE payloads are nowhere near this deep.
+const defaultMaxJSONDepth = 512
+
+// ErrJSONTooDeep is returned when a response nests deeper than the client's
+// configured maximum JSON depth.
+var ErrJSONTooDeep = errors.New("carsxe: JSON response nested too deeply")
+
+// WithMaxJSONDepth limits how deeply objects and arrays may nest in a
+// response body (default 512). Deeper responses are rejected with
+// ErrJSONTooDeep before they are decoded. n <= 0 restores the default.
+func WithMaxJSONDepth(n int) Option {
+	return func(c *Client) {
+		if n <= 0 {
+			n = defaultMaxJSONDepth
+		}
+		c.maxJSONDepth = n
+	}
+}
+
+// checkJSONDepth scans data without decoding it and reports ErrJSONTooDeep
+// if objects or arrays nest more than max levels.
+func checkJSONDepth(data []byte, max int) error {
+	depth := 0
+	inString, escaped := false, false
+	for _, b := range data {
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+			case b == '\\':
+				escaped = true
+			case b == '"':
+				inString = false
+			}
+			continue
+		}
+		switch b {
+		case '"':
+			inString = true
+		case '{', '[':
+			depth++
+			if depth > max {
+				return fmt.Errorf("%w (limit %d)", ErrJSONTooDeep, max)
+			}
+		case '}', ']':
+			depth--
+		}
+	}
+	return nil
+}
+
+// dateLayouts are the date formats toTime understands, most specific first.
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	"01/02/2006",
+	"1/2/2006",
+	"20060102",
+	"2006-01",
+}
+
+// toTime parses a date from a JSON string using dateLayouts, or a Unix
+// timestamp from a number. It returns false for missing or unparsable values.
+func toTime(v any) (time.Time, bool) {
+	switch t := v.(type) {
+	case string:
+		s := strings.TrimSpace(t)
+		for _, layout := range dateLayouts {
+			if ts, err := time.Parse(layout, s); err == nil {
+				return ts, true
+			}
+		}
+	case float64:
+		return time.Unix(int64(t), 0).UTC(), true
+	case json.Number: // under WithJSONNumbers
+		if i, err := t.Int64(); err == nil {
+			return time.Unix(i, 0).UTC(), true
+		}
+		if f, err := t.Float64(); err == nil {
+			return time.Unix(int64(f), 0).UTC(), true
+		}
+	case int:
+		return time.Unix(int64(t), 0).UTC(), true
+	case int64:
+		return time.Unix(t, 0).UTC(), true
+	}
+	return time.Time{}, false
+}
+
+// firstValue returns the first non-nil m[key] among keys.
+func firstValue(m map[string]any, keys ...string) any {
+	for _, k := range keys {
+		if v, ok := m[k]; ok && v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+// toBool interprets JSON booleans and common string spellings.
+func toBool(v any) bool {
+	switch b := v.(type) {
+	case bool:
+		return b
+	case string:
+		switch strings.ToLower(strings.TrimSpace(b)) {
+		case "true", "yes", "y", "1":
+			return true
+		}
+	case float64:
+		return b != 0
+	case json.Number:
+		f, err := b.Float64()
+		return err == nil && f != 0
+	}
+	return false
+}
+
+// ErrUnexpectedContentType is matched by errors.Is for a
+// *ContentTypeError, including one wrapped by an *APIError.
+var ErrUnexpectedContentType = errors.New("carsxe: unexpected content type")
+
+// WithJSONNumbers decodes numbers in the maps returned by the map-based
+// methods, GetContext and Raw fields as json.Number instead of float64, so
+// long IDs and large values keep every digit. Typed fields are unaffected.
+func WithJSONNumbers() Option {
+	return func(c *Client) { c.useNumber = true }
+}
+
+// WithStrictDecoding makes GetInto and GetAs fail with a *DecodeError when
+// the response has fields the target struct does not declare, to catch API
+// changes early. Like WithJSONNumbers, it also decodes numbers stored in
+// interface-typed fields as json.Number.
+func WithStrictDecoding() Option {
+	return func(c *Client) { c.strictDecoding = true }
+}
+
+// decodeJSON decodes b into v with the client's decoding options.
+func (c *Client) decodeJSON(b []byte, v any) error {
+	if len(b) == 0 {
+		return nil
+	}
+	if !c.useNumber && !c.strictDecoding {
+		return json.Unmarshal(b, v)
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if c.strictDecoding {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if dec.More() {
+		return errors.New("invalid character after top-level value")
+	}
+	return nil
+}
+
+// WithErrorBodyLimit caps how many bytes of a response body are quoted in
+// error messages (default 512). The full body stays available in
+// APIError.Body and ContentTypeError.Body. n <= 0 restores the default.
+func WithErrorBodyLimit(n int) Option {
+	return func(c *Client) {
+		if n <= 0 {
+			n = maxErrorBodyLen
+		}
+		c.errBodyLimit = n
+	}
+}
+
+// ContentTypeError is returned when a response is not JSON, for example an
+// HTML maintenance page or a block page from a firewall or proxy. For
+// non-2xx responses it is wrapped by the *APIError, so status checks such
+// as IsRateLimited keep working.
+type ContentTypeError struct {
+	StatusCode  int
+	ContentType string
+	// Title is the <title> of an HTML body, if any.
+	Title string
+	// Snippet is the start of the body, truncated to the limit set by
+	// WithErrorBodyLimit.
+	Snippet string
+	// Body is the complete raw body.
+	Body []byte
+}
+
+func (e *ContentTypeError) Error() string {
+	if e.Title != "" {
+		return fmt.Sprintf("carsxe: unexpected content type %q in response (%d): %s", e.ContentType, e.StatusCode, e.Title)
+	}
+	return fmt.Sprintf("carsxe: unexpected content type %q in response (%d): %s", e.ContentType, e.StatusCode, e.Snippet)
+}
+
+func (e *ContentTypeError) Is(target error) bool { return target == ErrUnexpectedContentType }
+
+// summary describes a markup body in a few words for APIError.Message, or
+// returns "" for plain text, which is better quoted as is.
+func (e *ContentTypeError) summary() string {
+	if e.Title != "" {
+		return e.Title
+	}
+	if !strings.HasPrefix(e.Snippet, "<") {
+		return ""
+	}
+	return fmt.Sprintf("non-JSON response (%s, %d bytes)", e.ContentType, len(e.Body))
+}
+
+// checkContentType rejects bodies that are declared as something other than
+// JSON and do not look like JSON either, so servers that mislabel JSON keep
+// working. Undeclared bodies are rejected only when they look like markup.
+// Empty bodies are accepted.
+func (c *Client) checkContentType(status int, contentType string, body []byte) *ContentTypeError {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || trimmed[0] == '{' || trimmed[0] == '[' {
+		return nil
+	}
+	if contentType == "" {
+		if trimmed[0] != '<' {
+			return nil
+		}
+	} else {
+		mediaType, _, _ := mime.ParseMediaType(contentType)
+		if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+			return nil
+		}
+	}
+	limit := c.errBodyLimit
+	if limit <= 0 {
+		limit = maxErrorBodyLen
+	}
+	return &ContentTypeError{
+		StatusCode:  status,
+		ContentType: contentType,
+		Title:       htmlTitle(trimmed),
+		Snippet:     truncate(string(trimmed), limit),
+		Body:        body,
+	}
+}
+
+// htmlTitleRe matches the title element of an HTML page.
+var htmlTitleRe = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+
+// htmlTitle returns the text of body's <title>, or "".
+func htmlTitle(body []byte) string {
+	m := htmlTitleRe.FindSubmatch(body)
+	if m == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(html.UnescapeString(string(m[1]))), " ")
+}
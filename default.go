@@ -0,0 +1,149 @@
+package carsxe
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrNotConfigured is returned by the package-level functions when Configure
+// has not been called.
+var ErrNotConfigured = errors.New("carsxe: default client not configured; call carsxe.Configure first")
+
+var (
+	defaultMu     sync.RWMutex
+	defaultClient *Client
+)
+
+// Configure sets up the shared client used by the package-level functions
+// such as carsxe.Specs. It is meant for scripts and examples; libraries should
+// create and pass around their own *Client instead.
+func Configure(apiKey string, opts ...Option) {
+	c := New(apiKey, opts...)
+	defaultMu.Lock()
+	defaultClient = c
+	defaultMu.Unlock()
+}
+
+// Default returns the client installed by Configure, or nil.
+func Default() *Client {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultClient
+}
+
+// defaultOrErr returns the default client or ErrNotConfigured.
+func defaultOrErr() (*Client, error) {
+	if c := Default(); c != nil {
+		return c, nil
+	}
+	return nil, ErrNotConfigured
+}
+
+// Specs calls SpecsContext on the default client.
+func Specs(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error) {
+	c, err := defaultOrErr()
+	if err != nil {
+		return nil, err
+	}
+	return c.SpecsContext(ctx, params, opts...)
+}
+
+// MarketValue calls MarketValueContext on the default client.
+func MarketValue(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error) {
+	c, err := defaultOrErr()
+	if err != nil {
+		return nil, err
+	}
+	return c.MarketValueContext(ctx, params, opts...)
+}
+
+// History calls HistoryContext on the default client.
+func History(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error) {
+	c, err := defaultOrErr()
+	if err != nil {
+		return nil, err
+	}
+	return c.HistoryContext(ctx, params, opts...)
+}
+
+// Recalls calls RecallsContext on the default client.
+func Recalls(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error) {
+	c, err := defaultOrErr()
+	if err != nil {
+		return nil, err
+	}
+	return c.RecallsContext(ctx, params, opts...)
+}
+
+// InternationalVINDecoder calls InternationalVINDecoderContext on the default client.
+func InternationalVINDecoder(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error) {
+	c, err := defaultOrErr()
+	if err != nil {
+		return nil, err
+	}
+	return c.InternationalVINDecoderContext(ctx, params, opts...)
+}
+
+// PlateDecoder calls PlateDecoderContext on the default client.
+func PlateDecoder(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error) {
+	c, err := defaultOrErr()
+	if err != nil {
+		return nil, err
+	}
+	return c.PlateDecoderContext(ctx, params, opts...)
+}
+
+// PlateImageRecognition calls PlateImageRecognitionContext on the default client.
+func PlateImageRecognition(ctx context.Context, imageURL string, opts ...CallOption) (map[string]any, error) {
+	c, err := defaultOrErr()
+	if err != nil {
+		return nil, err
+	}
+	return c.PlateImageRecognitionContext(ctx, imageURL, opts...)
+}
+
+// VinOCR calls VinOCRContext on the default client.
+func VinOCR(ctx context.Context, imageURL string, opts ...CallOption) (map[string]any, error) {
+	c, err := defaultOrErr()
+	if err != nil {
+		return nil, err
+	}
+	return c.VinOCRContext(ctx, imageURL, opts...)
+}
+
+// YearMakeModel calls YearMakeModelContext on the default client.
+func YearMakeModel(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error) {
+	c, err := defaultOrErr()
+	if err != nil {
+		return nil, err
+	}
+	return c.YearMakeModelContext(ctx, params, opts...)
+}
+
+// Images calls ImagesContext on the default client.
+func Images(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error) {
+	c, err := defaultOrErr()
+	if err != nil {
+		return nil, err
+	}
+	return c.ImagesContext(ctx, params, opts...)
+}
+
+// ObdCodesDecoder calls ObdCodesDecoderContext on the default client.
+func ObdCodesDecoder(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error) {
+	c, err := defaultOrErr()
+	if err != nil {
+		return nil, err
+	}
+	return c.ObdCodesDecoderContext(ctx, params, opts...)
+}
+
+// LienAndTheft calls LienAndTheftContext on the default client.
+func LienAndTheft(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error) {
+	c, err := defaultOrErr()
+	if err != nil {
+		return nil, err
+	}
+	return c.LienAndTheftContext(ctx, params, opts...)
+}
@@ -0,0 +1,168 @@
+// Package diskcache provides a carsxe.Cache that stores responses as files,
+// so cached lookups survive process restarts:
+//
+//	cache, err := diskcache.New("/var/cache/carsxe")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	client := carsxe.New(apiKey, carsxe.WithCache(cache),
+//		carsxe.WithStaleWhileRevalidate(time.Hour))
+//
+// Several processes may share a directory: entries are written to a
+// temporary file and renamed into place, so readers never see a partial
+// entry.
+package diskcache
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	carsxe "github.com/carsxe/carsxe-go-package"
+)
+
+// fileExt is the extension of entry files; Prune and Clear only touch
+// these.
+const fileExt = ".cxc"
+
+// headerLen is the size of the expiry time stored at the start of each file.
+const headerLen = 8
+
+// Cache is a carsxe.Cache storing one file per entry in a directory. It is
+// safe for concurrent use.
+type Cache struct {
+	dir string
+}
+
+var _ carsxe.Cache = (*Cache)(nil)
+
+// New returns a Cache storing its entries in dir, creating it if needed.
+func New(dir string) (*Cache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &Cache{dir: dir}, nil
+}
+
+// Dir returns the directory holding the entries.
+func (c *Cache) Dir() string { return c.dir }
+
+// Get returns the value stored for key if it has not expired. Expired and
+// unreadable entries are removed.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	path := c.path(key)
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	val, ok := decode(b)
+	if !ok {
+		os.Remove(path)
+		return nil, false
+	}
+	return val, true
+}
+
+// Set stores val for key for ttl; a ttl of zero or less never expires.
+// Write errors are ignored, leaving the entry uncached.
+func (c *Cache) Set(key string, val []byte, ttl time.Duration) {
+	var expires int64
+	if ttl > 0 {
+		expires = time.Now().Add(ttl).UnixNano()
+	}
+	b := make([]byte, 0, headerLen+len(val))
+	b = binary.BigEndian.AppendUint64(b, uint64(expires))
+	b = append(b, val...)
+
+	tmp, err := os.CreateTemp(c.dir, "tmp-*")
+	if err != nil {
+		return
+	}
+	_, err = tmp.Write(b)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.path(key))
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+}
+
+// Delete removes the entry for key, if any.
+func (c *Cache) Delete(key string) {
+	os.Remove(c.path(key))
+}
+
+// Prune removes expired and unreadable entries and returns how many were
+// removed. Entries are otherwise only removed when looked up after expiring,
+// so long-running processes may want to call it periodically.
+func (c *Cache) Prune() (int, error) {
+	return c.removeIf(func(b []byte) bool {
+		_, ok := decode(b)
+		return !ok
+	})
+}
+
+// Clear removes every entry.
+func (c *Cache) Clear() error {
+	_, err := c.removeIf(func([]byte) bool { return true })
+	return err
+}
+
+// removeIf deletes the entry files whose contents match drop.
+func (c *Cache) removeIf(drop func(b []byte) bool) (int, error) {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	var errs []error
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), fileExt) {
+			continue
+		}
+		path := filepath.Join(c.dir, e.Name())
+		b, err := os.ReadFile(path)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, err)
+			continue
+		}
+		if err == nil && !drop(b) {
+			continue
+		}
+		if err := os.Remove(path); err == nil {
+			n++
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	return n, errors.Join(errs...)
+}
+
+// path returns the file holding key. Keys are hashed since they contain
+// query strings.
+func (c *Cache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+fileExt)
+}
+
+// decode splits a file into its value, reporting false if it is malformed
+// or expired.
+func decode(b []byte) ([]byte, bool) {
+	if len(b) < headerLen {
+		return nil, false
+	}
+	expires := int64(binary.BigEndian.Uint64(b[:headerLen]))
+	if expires != 0 && time.Now().UnixNano() > expires {
+		return nil, false
+	}
+	return b[headerLen:], true
+}
@@ -0,0 +1,56 @@
+package carsxe
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// WithDryRun makes every call fail with a *DryRunError describing the
+// request instead of sending it, for debugging and support tickets.
+// Validation, headers and per-call options are applied as usual; the
+// cache, limits and retries are skipped.
+func WithDryRun() Option {
+	return func(c *Client) { c.dryRun = true }
+}
+
+// DryRunError is returned by calls made under WithDryRun. It holds the
+// request as it would have been sent, including the changes of
+// WithAuthenticator, with the API key and any Authorization header masked.
+type DryRunError struct {
+	Method string
+	// URL is the full request URL with the "key" parameter replaced by
+	// "***".
+	URL    string
+	Header http.Header
+	// Body is the request body, or nil when there is none or it is a
+	// streamed image upload.
+	Body []byte
+}
+
+func (e *DryRunError) Error() string {
+	return fmt.Sprintf("carsxe: dry run: %s %s", e.Method, e.URL)
+}
+
+// newDryRunError describes req without sending it.
+func (c *Client) newDryRunError(req *http.Request) *DryRunError {
+	if c.auth != nil {
+		if signed, err := c.authenticate(req); err == nil {
+			req = signed
+		}
+	}
+	e := &DryRunError{Method: req.Method, URL: SanitizeURL(req.URL.String()), Header: req.Header.Clone()}
+	if e.Header.Get("Authorization") != "" {
+		e.Header.Set("Authorization", "***")
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			var buf bytes.Buffer
+			io.Copy(&buf, body)
+			body.Close()
+			e.Body = buf.Bytes()
+		}
+	}
+	return e
+}
@@ -0,0 +1,100 @@
+package carsxe
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+// errImageURLRequired is returned by the URL-based image endpoints when no
+// URL is given.
+var errImageURLRequired = errors.New("image URL required")
+
+// SpecsContext => GET /specs (vin required; deepdata, disableIntVINDecoding optional)
+func (c *Client) SpecsContext(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error) {
+	if err := c.checkVIN(params); err != nil {
+		return nil, err
+	}
+	return c.GetContext(ctx, "specs", params, opts...)
+}
+
+// MarketValueContext => GET /v2/marketvalue (vin)
+func (c *Client) MarketValueContext(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error) {
+	if err := c.checkVIN(params); err != nil {
+		return nil, err
+	}
+	return c.GetContext(ctx, "v2/marketvalue", params, opts...)
+}
+
+// HistoryContext => GET /history (vin)
+func (c *Client) HistoryContext(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error) {
+	if err := c.checkVIN(params); err != nil {
+		return nil, err
+	}
+	return c.GetContext(ctx, "history", params, opts...)
+}
+
+// RecallsContext => GET /v1/recalls (vin)
+func (c *Client) RecallsContext(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error) {
+	if err := c.checkVIN(params); err != nil {
+		return nil, err
+	}
+	return c.GetContext(ctx, "v1/recalls", params, opts...)
+}
+
+// InternationalVINDecoderContext => GET /v1/international-vin-decoder (vin)
+func (c *Client) InternationalVINDecoderContext(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error) {
+	return c.GetContext(ctx, "v1/international-vin-decoder", params, opts...)
+}
+
+// PlateDecoderContext => GET /v2/platedecoder (plate, country, state?, district?)
+// The plate is normalized with NormalizePlate before it is sent.
+func (c *Client) PlateDecoderContext(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error) {
+	if plate, ok := params["plate"]; ok {
+		normalized, err := NormalizePlate(plate, params["country"])
+		if err != nil {
+			return nil, err
+		}
+		params = withParam(params, "plate", normalized)
+	}
+	return c.GetContext(ctx, "v2/platedecoder", params, opts...)
+}
+
+// PlateImageRecognitionContext => POST /platerecognition with JSON {"image": "<url>"}
+func (c *Client) PlateImageRecognitionContext(ctx context.Context, imageURL string, opts ...CallOption) (map[string]any, error) {
+	if strings.TrimSpace(imageURL) == "" {
+		return nil, errImageURLRequired
+	}
+	return c.postJSONContext(ctx, "platerecognition", map[string]string{"image": imageURL}, opts...)
+}
+
+// VinOCRContext => POST /v1/vinocr with JSON {"image": "<url>"}
+func (c *Client) VinOCRContext(ctx context.Context, imageURL string, opts ...CallOption) (map[string]any, error) {
+	if strings.TrimSpace(imageURL) == "" {
+		return nil, errImageURLRequired
+	}
+	return c.postJSONContext(ctx, "v1/vinocr", map[string]string{"image": imageURL}, opts...)
+}
+
+// YearMakeModelContext => GET /v1/ymm (year, make, model, trim?)
+func (c *Client) YearMakeModelContext(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error) {
+	return c.GetContext(ctx, "v1/ymm", params, opts...)
+}
+
+// ImagesContext => GET /images (make, model, optional year, trim, color, etc.)
+func (c *Client) ImagesContext(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error) {
+	return c.GetContext(ctx, "images", params, opts...)
+}
+
+// ObdCodesDecoderContext => GET /obdcodesdecoder (code)
+func (c *Client) ObdCodesDecoderContext(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error) {
+	if raw, ok := c.localOBD(params); ok {
+		return raw, nil
+	}
+	return c.GetContext(ctx, "obdcodesdecoder", params, opts...)
+}
+
+// LienAndTheftContext => GET /v1/lien-theft (vin)
+func (c *Client) LienAndTheftContext(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error) {
+	return c.GetContext(ctx, "v1/lien-theft", params, opts...)
+}
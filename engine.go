@@ -0,0 +1,85 @@
+package carsxe
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// EngineResult holds the drivetrain details of a vehicle.
+type EngineResult struct {
+	VIN string
+	// Engine is the free-form engine description, e.g. "3.0L L6 DOHC 24V".
+	Engine             string
+	DisplacementLiters float64
+	Cylinders          int
+	FuelType           string
+	Horsepower         int
+	Transmission       string
+	TransmissionSpeeds int
+	Drivetrain         string
+	// FuelTypeCode and DrivetrainCode hold the numeric codes the API
+	// returned when WithEnumDecoding translated them into labels.
+	FuelTypeCode   string
+	DrivetrainCode string
+	// Raw is the attributes object the fields were extracted from.
+	Raw map[string]any
+}
+
+var (
+	displacementRe = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*L\b`)
+	cylindersRe    = regexp.MustCompile(`\b(?:[VLIHW]|V-|I-|L-)(\d{1,2})\b`)
+)
+
+// EngineSpecs decodes vin with the Specs endpoint and extracts the engine
+// and transmission details. Numeric values are parsed leniently since the API
+// often returns them as strings with units.
+func (c *Client) EngineSpecs(ctx context.Context, vin string, opts ...CallOption) (*EngineResult, error) {
+	raw, err := c.SpecsContext(ctx, map[string]string{"vin": vin}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return c.newEngineResult(vin, raw), nil
+}
+
+// newEngineResult extracts an EngineResult from a Specs response.
+func (c *Client) newEngineResult(vin string, raw map[string]any) *EngineResult {
+	attrs := asMap(raw["attributes"])
+	if attrs == nil {
+		attrs = map[string]any{}
+	}
+	r := &EngineResult{
+		VIN:          vin,
+		Engine:       toString(attrs["engine"]),
+		Transmission: toString(attrs["transmission"]),
+		Raw:          attrs,
+	}
+	r.FuelType, r.FuelTypeCode = c.enumValue("fuel_type", attrs["fuel_type"])
+	r.Drivetrain, r.DrivetrainCode = c.enumValue("drivetrain", attrs["drivetrain"])
+	if in := asMap(raw["input"]); in != nil && toString(in["vin"]) != "" {
+		r.VIN = toString(in["vin"])
+	}
+
+	if f, ok := toFloat(attrs["engine_size"]); ok {
+		r.DisplacementLiters = f
+	} else if m := displacementRe.FindStringSubmatch(r.Engine); m != nil {
+		r.DisplacementLiters, _ = parseLeadingNumber(m[1])
+	}
+	if n, ok := toInt(attrs["engine_cylinders"]); ok {
+		r.Cylinders = n
+	} else if m := cylindersRe.FindStringSubmatch(strings.ToUpper(r.Engine)); m != nil {
+		r.Cylinders, _ = toInt(m[1])
+	}
+	for _, key := range []string{"horsepower", "engine_horsepower", "hp"} {
+		if n, ok := toInt(attrs[key]); ok {
+			r.Horsepower = n
+			break
+		}
+	}
+	if n, ok := toInt(attrs["transmission_speeds"]); ok {
+		r.TransmissionSpeeds = n
+	} else if n, ok := toInt(r.Transmission); ok && strings.Contains(strings.ToLower(r.Transmission), "speed") {
+		r.TransmissionSpeeds = n
+	}
+	return r
+}
@@ -0,0 +1,86 @@
+package carsxe
+
+import (
+	"strings"
+	"sync"
+)
+
+// enumTables maps response fields that the API may return as numeric codes to
+// a table of code labels. The codes follow NHTSA vPIC. Covered fields:
+//
+//   - fuel_type: primary fuel type
+//   - drivetrain (also drive_type): drive type
+//   - body_style (also body_class): body class
+var (
+	enumMu     sync.RWMutex
+	enumTables = map[string]map[string]string{
+		"fuel_type": {
+			"1": "Diesel", "2": "Electric", "3": "Compressed Natural Gas (CNG)",
+			"4": "Gasoline", "6": "Liquefied Petroleum Gas (LPG)", "7": "Liquefied Natural Gas (LNG)",
+			"8": "Hydrogen", "10": "Ethanol (E85)", "15": "Flexible Fuel Vehicle (FFV)",
+		},
+		"drivetrain": {
+			"1": "Front-Wheel Drive", "2": "4-Wheel Drive", "3": "All-Wheel Drive",
+			"4": "Rear-Wheel Drive", "5": "4x2",
+		},
+		"body_style": {
+			"1": "Convertible", "2": "Minivan", "3": "Coupe", "5": "Hatchback",
+			"7": "Sport Utility Vehicle (SUV)", "9": "Van", "13": "Sedan", "15": "Wagon",
+			"60": "Pickup",
+		},
+	}
+	// enumAliases lets alternative field names share a table.
+	enumAliases = map[string]string{
+		"drive_type": "drivetrain",
+		"body_class": "body_style",
+	}
+)
+
+// WithEnumDecoding translates numeric codes in the covered fields (see
+// DecodeEnum) into readable labels when building typed results. The typed
+// results keep the original code alongside the label.
+func WithEnumDecoding() Option {
+	return func(c *Client) { c.enumDecoding = true }
+}
+
+// RegisterEnum adds or replaces the label of code for field.
+func RegisterEnum(field, code, label string) {
+	field = enumField(field)
+	enumMu.Lock()
+	defer enumMu.Unlock()
+	if enumTables[field] == nil {
+		enumTables[field] = map[string]string{}
+	}
+	enumTables[field][code] = label
+}
+
+// DecodeEnum returns the label of a numeric code for field. Covered fields
+// are fuel_type, drivetrain (drive_type) and body_style (body_class); more
+// can be added with RegisterEnum.
+func DecodeEnum(field, code string) (string, bool) {
+	enumMu.RLock()
+	defer enumMu.RUnlock()
+	label, ok := enumTables[enumField(field)][strings.TrimSpace(code)]
+	return label, ok
+}
+
+func enumField(field string) string {
+	field = strings.ToLower(strings.TrimSpace(field))
+	if alias, ok := enumAliases[field]; ok {
+		return alias
+	}
+	return field
+}
+
+// enumValue returns the display value of v for field and, when v was a code
+// translated by WithEnumDecoding, the original code.
+func (c *Client) enumValue(field string, v any) (value, code string) {
+	value = toString(v)
+	if !c.enumDecoding {
+		return value, ""
+	}
+	if label, ok := DecodeEnum(field, value); ok {
+		return label, value
+	}
+	return value, ""
+}
@@ -0,0 +1,27 @@
+package carsxe
+
+// WithEnvelopeUnwrapping makes the client understand the
+// {"success": ..., "error": ..., "data": ...} envelope of CarsXE responses:
+// a 2xx response whose body reports "success": false fails with an
+// *APIError carrying the response's status and the envelope's message, and
+// the map-returning methods return the "data" object alone when the body has
+// one. GetRaw, GetStream and Response.Decode still see the complete body.
+//
+// The map-based convenience methods such as Specs keep returning the body
+// of failed calls instead of panicking.
+func WithEnvelopeUnwrapping() Option {
+	return func(c *Client) { c.unwrapEnvelope = true }
+}
+
+// decodeResult decodes a successful response body for the map-returning
+// methods, unwrapping the data payload under WithEnvelopeUnwrapping.
+func (c *Client) decodeResult(body []byte) (map[string]any, error) {
+	out, err := c.decodeMap(body)
+	if err != nil || !c.unwrapEnvelope {
+		return out, err
+	}
+	if data := asMap(out["data"]); data != nil {
+		return data, nil
+	}
+	return out, nil
+}
@@ -0,0 +1,286 @@
+package carsxe
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// maxErrorBodyLen caps how much of a response body is quoted in error messages.
+const maxErrorBodyLen = 512
+
+// APIError is returned when the CarsXE API responds with a non-2xx status,
+// or, under WithEnvelopeUnwrapping, with a body reporting "success": false.
+type APIError struct {
+	StatusCode int
+	Endpoint   string
+	// Body is the raw response body.
+	Body string
+	// Message and Code are taken from the JSON error envelope when present.
+	Message string
+	Code    string
+	// RequestID is the server's request identifier (X-Request-Id and
+	// similar headers), useful for support tickets; empty if not sent.
+	RequestID string
+	// WMI is the offline-decoded manufacturer identifier of the requested
+	// VIN, attached to 404 responses from VIN-based endpoints to help tell a
+	// mistyped VIN from one CarsXE has no data for.
+	WMI *WMIInfo
+
+	// decoded is the JSON body, kept so the map-based methods can keep
+	// returning error payloads instead of panicking.
+	decoded map[string]any
+	// bodyLimit caps the body quoted by Error; 0 means maxErrorBodyLen.
+	bodyLimit int
+	// cause is the *ContentTypeError of a non-JSON body.
+	cause error
+}
+
+func (e *APIError) Error() string {
+	detail := e.Message
+	if detail == "" {
+		limit := e.bodyLimit
+		if limit <= 0 {
+			limit = maxErrorBodyLen
+		}
+		detail = truncate(e.Body, limit)
+	}
+	if e.Code != "" {
+		detail = e.Code + ": " + detail
+	}
+	if e.WMI != nil {
+		detail += " (VIN WMI " + e.WMI.String() + ")"
+	}
+	if e.StatusCode >= 200 && e.StatusCode < 300 {
+		return fmt.Sprintf("carsxe: unsuccessful response (%d): %s", e.StatusCode, detail)
+	}
+	return fmt.Sprintf("carsxe: non-2xx response (%d): %s", e.StatusCode, detail)
+}
+
+// Unwrap returns the *ContentTypeError when the body was not JSON, such as
+// an HTML error page, or nil.
+func (e *APIError) Unwrap() error { return e.cause }
+
+// requestIDHeaders are checked, in order, for APIError.RequestID.
+var requestIDHeaders = []string{"X-Request-Id", "X-Amzn-Requestid", "X-Amz-Cf-Id", "Cf-Ray"}
+
+// requestID returns the first request identifier header present in h.
+func requestID(h http.Header) string {
+	for _, name := range requestIDHeaders {
+		if v := h.Get(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// StatusCode returns the HTTP status of the *APIError in err's chain, or 0.
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsRateLimited reports whether err is a 429 Too Many Requests response.
+func IsRateLimited(err error) bool { return StatusCode(err) == http.StatusTooManyRequests }
+
+// IsNotFound reports whether err is a 404 Not Found response, e.g. for a VIN
+// CarsXE has no data for.
+func IsNotFound(err error) bool { return StatusCode(err) == http.StatusNotFound }
+
+// IsUnauthorized reports whether err is a 401 or 403 response, typically
+// caused by an invalid or disabled API key.
+func IsUnauthorized(err error) bool {
+	s := StatusCode(err)
+	return s == http.StatusUnauthorized || s == http.StatusForbidden
+}
+
+// FieldError describes a single invalid input reported by the API.
+type FieldError struct {
+	Name   string
+	Reason string
+}
+
+// ValidationError is returned for 422 responses whose body lists field-level
+// validation problems. It unwraps to the underlying *APIError.
+type ValidationError struct {
+	Fields []FieldError
+	err    *APIError
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		parts[i] = f.Name + ": " + f.Reason
+	}
+	return fmt.Sprintf("carsxe: validation failed (%d): %s", e.err.StatusCode, strings.Join(parts, "; "))
+}
+
+func (e *ValidationError) Unwrap() error { return e.err }
+
+// DecodeError is returned when a successful response cannot be decoded into
+// the requested type, typically because the API changed a field's type. Raw
+// holds the body decoded as a generic map so callers can keep going; it is
+// nil if the body is not a JSON object.
+type DecodeError struct {
+	Endpoint string
+	Raw      map[string]any
+	// Partial reports whether the typed value returned alongside the error
+	// holds the fields that did decode (see WithLenientDecoding).
+	Partial bool
+	Err     error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("carsxe: failed to decode %s response: %v", e.Endpoint, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error { return e.Err }
+
+// newStatusError builds the error for a non-2xx response, or returns nil for
+// successful statuses.
+func newStatusError(endpoint string, status int, body []byte, decoded map[string]any) error {
+	if status >= 200 && status < 300 {
+		return nil
+	}
+	apiErr := &APIError{
+		StatusCode: status,
+		Endpoint:   strings.TrimLeft(endpoint, "/"),
+		Body:       string(body),
+		decoded:    decoded,
+	}
+	apiErr.Message, apiErr.Code = errorEnvelope(decoded)
+	if status == http.StatusUnprocessableEntity {
+		if fields := fieldErrors(decoded); len(fields) > 0 {
+			return &ValidationError{Fields: fields, err: apiErr}
+		}
+	}
+	return apiErr
+}
+
+// newEnvelopeError builds the error for a 2xx response whose body reports
+// {"success": false}, or returns nil.
+func newEnvelopeError(endpoint string, status int, body []byte, decoded map[string]any) error {
+	success, ok := decoded["success"]
+	if !ok || success == nil || toBool(success) {
+		return nil
+	}
+	apiErr := &APIError{
+		StatusCode: status,
+		Endpoint:   strings.TrimLeft(endpoint, "/"),
+		Body:       string(body),
+		decoded:    decoded,
+	}
+	apiErr.Message, apiErr.Code = errorEnvelope(decoded)
+	return apiErr
+}
+
+// attachWMI enriches a 404 APIError for a VIN-based request with the VIN's
+// offline WMI information.
+func attachWMI(err error, vin string) {
+	var apiErr *APIError
+	if vin == "" || !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		return
+	}
+	if info, ok := LookupWMI(vin); ok {
+		apiErr.WMI = &info
+	}
+}
+
+// errorEnvelope extracts the message and code from common error envelopes
+// such as {"error": "..."} or {"error": {"message": "...", "code": "..."}}.
+func errorEnvelope(body map[string]any) (message, code string) {
+	if body == nil {
+		return "", ""
+	}
+	message = stringField(body, "message")
+	code = stringField(body, "code")
+	switch e := body["error"].(type) {
+	case string:
+		if message == "" {
+			message = e
+		}
+	case map[string]any:
+		if m := stringField(e, "message"); m != "" {
+			message = m
+		}
+		if c := stringField(e, "code"); c != "" {
+			code = c
+		}
+	}
+	return message, code
+}
+
+// fieldErrors parses the "errors" member of a validation response. Both the
+// {"errors": {"vin": ["reason"]}} and the
+// {"errors": [{"field": "vin", "message": "reason"}]} shapes are understood.
+func fieldErrors(body map[string]any) []FieldError {
+	var out []FieldError
+	switch errs := body["errors"].(type) {
+	case map[string]any:
+		names := make([]string, 0, len(errs))
+		for name := range errs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			switch r := errs[name].(type) {
+			case string:
+				out = append(out, FieldError{Name: name, Reason: r})
+			case []any:
+				for _, item := range r {
+					if s, ok := item.(string); ok {
+						out = append(out, FieldError{Name: name, Reason: s})
+					}
+				}
+			}
+		}
+	case []any:
+		for _, item := range errs {
+			m, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			name := firstString(m, "field", "name", "param")
+			reason := firstString(m, "reason", "message", "error")
+			if name != "" {
+				out = append(out, FieldError{Name: name, Reason: reason})
+			}
+		}
+	}
+	return out
+}
+
+// stringField returns m[key] when it is a string or a number.
+func stringField(m map[string]any, key string) string {
+	switch v := m[key].(type) {
+	case string:
+		return v
+	case float64, json.Number, bool:
+		return fmt.Sprint(v)
+	}
+	return ""
+}
+
+// firstString returns the first non-empty stringField among keys.
+func firstString(m map[string]any, keys ...string) string {
+	for _, k := range keys {
+		if s := stringField(m, k); s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// truncate shortens s to at most n bytes, marking the cut.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "...(truncated)"
+}
@@ -0,0 +1,104 @@
+package carsxe
+
+import (
+	"expvar"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// WithExpvar publishes request counters and a latency summary through the
+// standard expvar package, visible on /debug/vars, under the name prefix:
+//
+//	requests   total network requests
+//	responses  requests by status class ("2xx", "4xx", ...)
+//	errors     failed requests by status class, "network" when no response
+//	cache      cache "hit" and "miss" counts
+//	latency    {"count", "mean_ms", "max_ms"} over all requests
+//
+// Clients using distinct prefixes are reported separately; clients sharing a
+// prefix share the counters.
+func WithExpvar(prefix string) Option {
+	return func(c *Client) { c.metrics = append(c.metrics, expvarMetricsFor(prefix)) }
+}
+
+var (
+	expvarMu     sync.Mutex
+	expvarByName = map[string]*expvarMetrics{}
+)
+
+// expvarMetrics implements Metrics on top of an expvar.Map.
+type expvarMetrics struct {
+	requests  expvar.Int
+	responses expvar.Map
+	errors    expvar.Map
+	cache     expvar.Map
+
+	mu    sync.Mutex
+	count int64
+	total time.Duration
+	max   time.Duration
+}
+
+func expvarMetricsFor(prefix string) *expvarMetrics {
+	expvarMu.Lock()
+	defer expvarMu.Unlock()
+	if m, ok := expvarByName[prefix]; ok {
+		return m
+	}
+	m := &expvarMetrics{}
+	root := new(expvar.Map).Init()
+	root.Set("requests", &m.requests)
+	root.Set("responses", m.responses.Init())
+	root.Set("errors", m.errors.Init())
+	root.Set("cache", m.cache.Init())
+	root.Set("latency", expvar.Func(m.latency))
+	expvar.Publish(prefix, root)
+	expvarByName[prefix] = m
+	return m
+}
+
+func (m *expvarMetrics) ObserveRequest(_ string, status int, d time.Duration, err error) {
+	m.requests.Add(1)
+	class := statusClass(status)
+	if status != 0 {
+		m.responses.Add(class, 1)
+	}
+	if err != nil {
+		m.errors.Add(class, 1)
+	}
+	m.mu.Lock()
+	m.count++
+	m.total += d
+	if d > m.max {
+		m.max = d
+	}
+	m.mu.Unlock()
+}
+
+func (m *expvarMetrics) ObserveCache(_ string, hit bool) {
+	if hit {
+		m.cache.Add("hit", 1)
+	} else {
+		m.cache.Add("miss", 1)
+	}
+}
+
+func (m *expvarMetrics) latency() any {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	mean := 0.0
+	if m.count > 0 {
+		mean = float64(m.total.Milliseconds()) / float64(m.count)
+	}
+	return map[string]any{"count": m.count, "mean_ms": mean, "max_ms": m.max.Milliseconds()}
+}
+
+// statusClass buckets an HTTP status as "2xx", "4xx", etc., or "network"
+// when no response was received.
+func statusClass(status int) string {
+	if status == 0 {
+		return "network"
+	}
+	return fmt.Sprintf("%dxx", status/100)
+}
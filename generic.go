@@ -0,0 +1,112 @@
+package carsxe
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+)
+
+// GetInto performs a GET request and decodes the JSON body straight into a
+// value of type T, skipping the intermediate map[string]any. It is a
+// package-level function because methods cannot have type parameters.
+//
+// Fields of type json.RawMessage are filled with the exact bytes of their
+// section of the response without parsing them, which is cheaper for large
+// sections that are only forwarded and keeps them byte-for-byte intact:
+//
+//	type specs struct {
+//		Input      struct{ VIN string `json:"vin"` } `json:"input"`
+//		Attributes json.RawMessage                   `json:"attributes"`
+//	}
+//	s, err := carsxe.GetInto[specs](ctx, client, "specs", params)
+//
+// A raw section can be decoded later with json.Unmarshal(s.Attributes, &v).
+//
+// If the body does not match T, or has fields T lacks under
+// WithStrictDecoding, GetInto returns a *DecodeError carrying the body as a
+// generic map. By default the returned T is then the zero value;
+// with WithLenientDecoding it holds every field that did decode.
+func GetInto[T any](ctx context.Context, c *Client, endpoint string, params map[string]string, opts ...CallOption) (T, error) {
+	var out T
+	resp, err := c.GetRaw(ctx, endpoint, params, opts...)
+	if err != nil {
+		return out, err
+	}
+	if err := c.decodeJSON(resp.Body, &out); err != nil {
+		raw, _ := c.decodeMap(resp.Body)
+		derr := &DecodeError{Endpoint: strings.TrimLeft(endpoint, "/"), Raw: raw, Partial: c.lenient, Err: err}
+		if !c.lenient {
+			var zero T
+			return zero, derr
+		}
+		c.warnDecode(ctx, derr)
+		return out, derr
+	}
+	return out, nil
+}
+
+// GetAs is like GetInto but decodes the map returned by GetContext, so
+// client options that reshape results, such as WithEnvelopeUnwrapping and
+// WithEnumDecoding, apply. Use it to populate your own struct for endpoints
+// or fields the typed methods do not cover yet:
+//
+//	type lien struct {
+//		VIN    string `json:"vin"`
+//		Status string `json:"status"`
+//	}
+//	l, err := carsxe.GetAs[lien](ctx, client, "v1/lien-theft", params)
+//
+// Errors are reported as by GetInto.
+func GetAs[T any](ctx context.Context, c *Client, endpoint string, params map[string]string, opts ...CallOption) (T, error) {
+	var out T
+	raw, err := c.GetContext(ctx, endpoint, params, opts...)
+	if err != nil {
+		return out, err
+	}
+	b, err := json.Marshal(raw)
+	if err == nil {
+		err = c.decodeJSON(b, &out)
+	}
+	if err != nil {
+		derr := &DecodeError{Endpoint: strings.TrimLeft(endpoint, "/"), Raw: raw, Partial: c.lenient, Err: err}
+		if !c.lenient {
+			var zero T
+			return zero, derr
+		}
+		c.warnDecode(ctx, derr)
+		return out, derr
+	}
+	return out, nil
+}
+
+// DecodeInto fills v, a pointer, from m, such as a map returned by the
+// map-based methods or a Raw field. It follows encoding/json rules: fields
+// are matched by their json tag or, ignoring case, by name, and values must
+// have the matching JSON type (use the ",string" tag option for numbers the
+// API sends as strings). Fields that do decode are kept when an error is
+// returned.
+func DecodeInto(m map[string]any, v any) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
+
+// warnDecode logs a *DecodeError returned under WithLenientDecoding.
+func (c *Client) warnDecode(ctx context.Context, derr *DecodeError) {
+	if c.logger != nil {
+		c.logger.LogAttrs(ctx, slog.LevelWarn, "carsxe: response did not match the expected type",
+			slog.String(LogKeyEndpoint, derr.Endpoint), slog.String(LogKeyError, derr.Err.Error()))
+	}
+}
+
+// WithLenientDecoding makes GetInto and GetAs return the partially decoded
+// value along with its *DecodeError, and log a warning, instead of
+// discarding it. The typed results built from maps (EngineResult,
+// RecallsResult, ...) are always lenient: fields whose type changed are
+// left at their zero value and Raw keeps the original data.
+func WithLenientDecoding() Option {
+	return func(c *Client) { c.lenient = true }
+}
@@ -1,3 +1,18 @@
 module github.com/carsxe/carsxe-go-package
 
 go 1.25.1
+
+require golang.org/x/time v0.14.0
+
+require (
+	github.com/andybalholm/brotli v1.2.5
+	go.opentelemetry.io/otel v1.38.0
+	go.opentelemetry.io/otel/metric v1.38.0
+	go.opentelemetry.io/otel/trace v1.38.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.3 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
+)
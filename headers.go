@@ -0,0 +1,79 @@
+package carsxe
+
+import (
+	"net/http"
+	"strings"
+	"unicode"
+)
+
+// Version is the version of this package, reported in the default
+// User-Agent header.
+const Version = "1.0.0"
+
+// defaultUserAgent is sent unless WithHeaders or WithCallHeader set one.
+// WithUserAgentSuffix extends it.
+const defaultUserAgent = "carsxe-go/" + Version
+
+// WithHeaders adds h to every request the client sends, e.g. headers
+// required by a corporate proxy. A User-Agent in h replaces the default
+// "carsxe-go/<version>". Content-Type is never overridden on requests that
+// set their own, such as JSON and multipart POSTs. Calling it again adds to
+// the headers.
+func WithHeaders(h http.Header) Option {
+	return func(c *Client) {
+		if c.headers == nil {
+			c.headers = http.Header{}
+		}
+		for k, vs := range h {
+			c.headers[http.CanonicalHeaderKey(k)] = append([]string(nil), vs...)
+		}
+	}
+}
+
+// WithUserAgentSuffix appends s to the default User-Agent, producing for
+// example "carsxe-go/1.0.0 myapp/3.4". Control characters such as newlines
+// are removed from s to prevent header injection.
+func WithUserAgentSuffix(s string) Option {
+	return func(c *Client) {
+		c.userAgent = defaultUserAgent
+		s = strings.Join(strings.Fields(strings.Map(func(r rune) rune {
+			if unicode.IsControl(r) {
+				return ' '
+			}
+			return r
+		}, s)), " ")
+		if s != "" {
+			c.userAgent += " " + s
+		}
+	}
+}
+
+// WithCallHeader sets header key to value on a single call, replacing any
+// client-wide value from WithHeaders. Content-Type is protected as in
+// WithHeaders.
+func WithCallHeader(key, value string) CallOption {
+	return func(co *callOptions) {
+		if co.header == nil {
+			co.header = http.Header{}
+		}
+		co.header.Set(key, value)
+	}
+}
+
+// applyHeaders sets the default, client-wide and per-call headers on req.
+func (c *Client) applyHeaders(req *http.Request, co *callOptions) {
+	ua := c.userAgent
+	if ua == "" {
+		ua = defaultUserAgent
+	}
+	req.Header.Set("User-Agent", ua)
+	hasContentType := req.Header.Get("Content-Type") != ""
+	for _, h := range []http.Header{c.headers, co.header} {
+		for k, vs := range h {
+			if k == "Content-Type" && hasContentType {
+				continue
+			}
+			req.Header[k] = append([]string(nil), vs...)
+		}
+	}
+}
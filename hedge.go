@@ -0,0 +1,105 @@
+package carsxe
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// WithHedging sends up to maxHedges extra copies of a GET request when it
+// has not completed within delay, uses whichever response arrives first and
+// cancels the others. A copy is also sent right away when an attempt fails
+// with a network error. It cuts tail latency at the cost of extra,
+// billable requests. Each copy is an attempt of its own: it reserves its
+// credits under WithBudget, needs the circuit breaker's permission, goes
+// through the rate and concurrency limits and takes a WithAPIKeys key, and
+// is skipped when any of these refuses it. Under WithMaxConcurrency a copy
+// only starts once a slot is free. Other methods, and clients using
+// WithSerialized, are never hedged. A delay of zero or less, or a maxHedges
+// below 1, disables hedging.
+func WithHedging(delay time.Duration, maxHedges int) Option {
+	return func(c *Client) {
+		c.hedgeDelay = delay
+		c.maxHedges = max(maxHedges, 0)
+	}
+}
+
+// hedgeResult is the outcome of one copy of a hedged request. skipped
+// reports a copy that could not be reserved and was never sent.
+type hedgeResult struct {
+	resp    *Response
+	err     error
+	skipped bool
+}
+
+// hedgedRoundTrip sends the request of a, which it finishes, with
+// WithHedging applied. Extra copies reserve attempts of their own.
+func (c *Client) hedgedRoundTrip(a *attempt, endpoint string, co *callOptions) (*Response, error) {
+	if c.hedgeDelay <= 0 || c.maxHedges < 1 || c.serial != nil || a.req.Method != http.MethodGet {
+		resp, err := c.roundTrip(a.signed, endpoint)
+		a.finish(resp, err)
+		return resp, err
+	}
+	parent := a.req.Context() // copies' requests carry ctx instead
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+	results := make(chan hedgeResult, c.maxHedges+1)
+	run := func(a *attempt) {
+		resp, err := c.roundTrip(a.signed.Clone(ctx), endpoint)
+		if err != nil && resp == nil && ctx.Err() != nil && parent.Err() == nil {
+			a.finishCancelled(err)
+		} else {
+			a.finish(resp, err)
+		}
+		results <- hedgeResult{resp: resp, err: err}
+	}
+	hedge := func() {
+		go func() {
+			h, err := c.reserveAttempt(a.req.Clone(ctx), endpoint, co)
+			if err != nil {
+				results <- hedgeResult{err: err, skipped: true}
+				return
+			}
+			run(h)
+		}()
+	}
+
+	go run(a)
+	inflight, hedges := 1, 0
+	timer := time.NewTimer(c.hedgeDelay)
+	defer timer.Stop()
+	var last hedgeResult
+	for {
+		select {
+		case r := <-results:
+			inflight--
+			if r.skipped {
+				hedges = c.maxHedges // later copies would be refused too
+			} else {
+				// A response, even an error status, is an answer; only
+				// failures to get one wait for the other copies.
+				if r.err == nil || r.resp != nil {
+					return r.resp, r.err
+				}
+				last = r
+				if hedges < c.maxHedges && ctx.Err() == nil {
+					hedges++
+					inflight++
+					hedge()
+					timer.Reset(c.hedgeDelay)
+					continue
+				}
+			}
+			if inflight == 0 {
+				return last.resp, last.err
+			}
+		case <-timer.C:
+			if hedges < c.maxHedges && ctx.Err() == nil {
+				hedges++
+				inflight++
+				hedge()
+				timer.Reset(c.hedgeDelay)
+			}
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package carsxe
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+)
+
+// HistoryResult is the typed form of a History response.
+type HistoryResult struct {
+	VIN    string
+	Events []HistoryEvent
+	// Raw is the decoded response body.
+	Raw map[string]any
+}
+
+// HistoryEvent is a single record from a vehicle history report.
+type HistoryEvent struct {
+	// Date is zero when the record carries no recognizable date.
+	Date time.Time
+	// Type is the report section the record came from, e.g. "titleRecords".
+	Type string
+	// Mileage is the odometer reading recorded with the event, or 0.
+	Mileage     int
+	Location    string
+	Description string
+	// Source is the reporting entity, such as a state agency or insurer.
+	Source string
+	Raw    map[string]any
+}
+
+// historyDateKeys are the record fields tried, in order, for an event date.
+var historyDateKeys = []string{
+	"date", "eventDate", "event_date", "recordDate", "record_date",
+	"titleIssueDate", "title_issue_date", "reportedDate", "date_reported",
+}
+
+// Record fields tried, in order, for the other HistoryEvent fields.
+var (
+	historyMileageKeys     = []string{"mileage", "odometer", "odometerReading", "odometer_reading"}
+	historyDescriptionKeys = []string{"description", "details", "event", "eventType", "event_type", "remarks", "brand"}
+	historySourceKeys      = []string{"source", "reportingEntity", "reporting_entity", "provider", "entity", "agency"}
+)
+
+// HistoryTyped is like History but honors ctx, returns errors and decodes
+// the response into a HistoryResult whose events are sorted by date, undated
+// events last.
+func (c *Client) HistoryTyped(ctx context.Context, params map[string]string, opts ...CallOption) (*HistoryResult, error) {
+	raw, err := c.HistoryContext(ctx, params, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return newHistoryResult(params["vin"], raw), nil
+}
+
+// HistorySince returns the history events of vin dated strictly after
+// since. The API has no server-side filter, so the full report is fetched
+// and filtered locally; undated events are left out because they cannot be
+// placed relative to since.
+func (c *Client) HistorySince(ctx context.Context, vin string, since time.Time, opts ...CallOption) (*HistoryResult, error) {
+	r, err := c.HistoryTyped(ctx, map[string]string{"vin": vin}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	kept := r.Events[:0]
+	for _, e := range r.Events {
+		if !e.Date.IsZero() && e.Date.After(since) {
+			kept = append(kept, e)
+		}
+	}
+	r.Events = kept
+	return r, nil
+}
+
+// newHistoryResult collects every array of records in a History response
+// into events.
+func newHistoryResult(vin string, raw map[string]any) *HistoryResult {
+	r := &HistoryResult{VIN: vin, Raw: raw}
+	body := raw
+	if data := asMap(raw["data"]); data != nil {
+		body = data
+	}
+	if v := toString(body["vin"]); v != "" {
+		r.VIN = v
+	}
+	r.collect(body, 0)
+	sort.SliceStable(r.Events, func(i, j int) bool {
+		a, b := r.Events[i], r.Events[j]
+		if a.Date.IsZero() != b.Date.IsZero() {
+			return b.Date.IsZero()
+		}
+		if !a.Date.Equal(b.Date) {
+			return a.Date.Before(b.Date)
+		}
+		return a.Type < b.Type
+	})
+	return r
+}
+
+// maxHistoryDepth bounds how deeply nested report sections are searched for
+// records.
+const maxHistoryDepth = 3
+
+// collect adds the records of every array in section, and in its nested
+// sections, to r's events.
+func (r *HistoryResult) collect(section map[string]any, depth int) {
+	for name, v := range section {
+		if sub := asMap(v); sub != nil && depth < maxHistoryDepth {
+			r.collect(sub, depth+1)
+			continue
+		}
+		for _, item := range asSlice(v) {
+			if m := asMap(item); m != nil {
+				r.Events = append(r.Events, newHistoryEvent(name, m))
+			}
+		}
+	}
+}
+
+// newHistoryEvent maps a record of section onto a HistoryEvent.
+func newHistoryEvent(section string, m map[string]any) HistoryEvent {
+	e := HistoryEvent{
+		Type:        section,
+		Location:    historyLocation(m),
+		Description: firstString(m, historyDescriptionKeys...),
+		Source:      firstString(m, historySourceKeys...),
+		Raw:         m,
+	}
+	e.Date, _ = toTime(firstValue(m, historyDateKeys...))
+	e.Mileage, _ = toInt(firstValue(m, historyMileageKeys...))
+	return e
+}
+
+// historyLocation returns a record's "location", or its city, state and
+// country joined with commas.
+func historyLocation(m map[string]any) string {
+	if loc := firstString(m, "location"); loc != "" {
+		return loc
+	}
+	var parts []string
+	for _, k := range []string{"city", "state", "country"} {
+		if s := firstString(m, k); s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,56 @@
+package carsxe
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// WithRequestHook calls fn with every outgoing HTTP request just before it
+// is sent, including each retry attempt. fn may add headers, e.g. to
+// propagate a trace context taken from req.Context(). It may be given
+// several times; hooks run in order.
+func WithRequestHook(fn func(req *http.Request)) Option {
+	return func(c *Client) { c.requestHooks = append(c.requestHooks, fn) }
+}
+
+// WithResponseHook calls fn after every HTTP attempt with the response and
+// the time taken to receive it in full, whatever its status. resp is nil
+// when no response was received. resp.Body holds a copy of the body that
+// fn may read. It may be given several times; hooks run in order.
+func WithResponseHook(fn func(resp *http.Response, d time.Duration)) Option {
+	return func(c *Client) { c.responseHooks = append(c.responseHooks, fn) }
+}
+
+// runRequestHooks calls the request hooks, recovering from panics.
+func (c *Client) runRequestHooks(req *http.Request) {
+	for _, fn := range c.requestHooks {
+		c.safeHook(req, func() { fn(req) })
+	}
+}
+
+// runResponseHooks calls the response hooks, each with its own reader over
+// body, recovering from panics.
+func (c *Client) runResponseHooks(req *http.Request, resp *http.Response, body []byte, d time.Duration) {
+	for _, fn := range c.responseHooks {
+		if resp != nil {
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+		}
+		c.safeHook(req, func() { fn(resp, d) })
+	}
+}
+
+// safeHook runs fn, logging instead of propagating a panic so a faulty hook
+// cannot break the request.
+func (c *Client) safeHook(req *http.Request, fn func()) {
+	defer func() {
+		if r := recover(); r != nil && c.logger != nil {
+			c.logger.LogAttrs(req.Context(), slog.LevelError, "carsxe hook panicked",
+				slog.String(LogKeyMethod, req.Method), slog.String(LogKeyError, fmt.Sprint(r)))
+		}
+	}()
+	fn()
+}
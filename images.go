@@ -0,0 +1,255 @@
+package carsxe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ColorOptions returns the distinct colors the Images endpoint knows for a
+// vehicle, sorted alphabetically. year may be empty. A vehicle without color
+// information yields an empty slice rather than an error.
+func (c *Client) ColorOptions(ctx context.Context, make, model, year string, opts ...CallOption) ([]string, error) {
+	resp, err := c.ImagesContext(ctx, map[string]string{"make": make, "model": model, "year": year}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return imageColors(resp), nil
+}
+
+// imageColors collects colors from a top-level "colors" list and from the
+// "color" of each image, de-duplicated case-insensitively.
+func imageColors(resp map[string]any) []string {
+	seen := map[string]bool{}
+	colors := []string{}
+	add := func(v any) {
+		name := toString(v)
+		if m := asMap(v); m != nil {
+			name = firstString(m, "name", "color")
+		}
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		colors = append(colors, name)
+	}
+	for _, v := range asSlice(resp["colors"]) {
+		add(v)
+	}
+	for _, img := range asSlice(resp["images"]) {
+		if m := asMap(img); m != nil {
+			add(m["color"])
+		}
+	}
+	sort.Slice(colors, func(i, j int) bool {
+		return strings.ToLower(colors[i]) < strings.ToLower(colors[j])
+	})
+	return colors
+}
+
+// ImagesResult is the typed form of an Images response.
+type ImagesResult struct {
+	Images []VehicleImage
+	// Raw is the decoded response body.
+	Raw map[string]any
+
+	client *Client
+}
+
+// VehicleImage is one image returned by the Images endpoint.
+type VehicleImage struct {
+	URL          string
+	ThumbnailURL string
+	Width        int
+	Height       int
+	Color        string
+	// Angle is the viewpoint of the image, e.g. "front" or "side", when the
+	// API reports it.
+	Angle string
+	Raw   map[string]any
+}
+
+// ImagesTyped is like Images but honors ctx, returns errors and decodes the
+// response into an ImagesResult.
+func (c *Client) ImagesTyped(ctx context.Context, params map[string]string, opts ...CallOption) (*ImagesResult, error) {
+	raw, err := c.ImagesContext(ctx, params, opts...)
+	if err != nil {
+		return nil, err
+	}
+	r := &ImagesResult{Raw: raw, client: c}
+	for _, item := range asSlice(raw["images"]) {
+		m := asMap(item)
+		if m == nil {
+			continue
+		}
+		img := VehicleImage{
+			URL:          firstString(m, "link", "url"),
+			ThumbnailURL: firstString(m, "thumbnailLink", "thumbnail", "thumbnail_url"),
+			Color:        firstString(m, "color"),
+			Angle:        firstString(m, "angle", "view"),
+			Raw:          m,
+		}
+		img.Width, _ = toInt(m["width"])
+		img.Height, _ = toInt(m["height"])
+		r.Images = append(r.Images, img)
+	}
+	return r, nil
+}
+
+// DownloadImage fetches the image at img.URL and writes it to w. The
+// request goes through the client's HTTP client and transport options but
+// carries no API key, and a non-2xx status is an error.
+func (c *Client) DownloadImage(ctx context.Context, img VehicleImage, w io.Writer) error {
+	if img.URL == "" {
+		return errors.New("carsxe: image has no URL")
+	}
+	req, err := http.NewRequestWithContext(ctxOrBackground(ctx), http.MethodGet, img.URL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", defaultUserAgent)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("downloading %s: %w", img.URL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("downloading %s: %s", img.URL, resp.Status)
+	}
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// DownloadAll downloads every image of r into dir, which must exist, with
+// up to 4 downloads at once. Files are named by position, 001.jpg,
+// 002.png and so on, with the extension taken from the image URL. It returns
+// the path of each image in order, or "" for images that failed, along with
+// the joined errors of the failures.
+func (r *ImagesResult) DownloadAll(ctx context.Context, dir string) ([]string, error) {
+	if r.client == nil {
+		return nil, errors.New("carsxe: DownloadAll needs a result from ImagesTyped")
+	}
+	ctx = ctxOrBackground(ctx)
+	paths := make([]string, len(r.Images))
+	errs := make([]error, len(r.Images))
+	sem := make(chan struct{}, defaultBatchConcurrency)
+	var wg sync.WaitGroup
+	for i, img := range r.Images {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+			defer func() { <-sem }()
+			name := filepath.Join(dir, fmt.Sprintf("%03d%s", i+1, imageExt(img.URL)))
+			if errs[i] = r.client.downloadFile(ctx, img, name); errs[i] == nil {
+				paths[i] = name
+			}
+		}()
+	}
+	wg.Wait()
+	return paths, errors.Join(errs...)
+}
+
+// downloadFile downloads img to the file name, removing it on failure.
+func (c *Client) downloadFile(ctx context.Context, img VehicleImage, name string) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	err = c.DownloadImage(ctx, img, f)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(name)
+	}
+	return err
+}
+
+// imageExt returns the image file extension of rawURL's path, or ".jpg".
+func imageExt(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil {
+		if ext := strings.ToLower(path.Ext(u.Path)); imageExtensions[ext] {
+			return ext
+		}
+	}
+	return ".jpg"
+}
+
+// ImagesPage is one page of Images results.
+type ImagesPage struct {
+	// Page is the 1-based page number.
+	Page int
+	// Total is the number of images across all pages, when the API reports
+	// it; otherwise 0.
+	Total  int
+	Images []any
+	// Raw is the decoded response body.
+	Raw map[string]any
+}
+
+// ImagesPaginated returns an iterator over the pages of an Images query,
+// starting at params["page"] (default 1) and advancing the "page" parameter
+// until the API reports no further pages or returns an empty page. An error,
+// including ctx's once it is cancelled, is yielded as the last element.
+//
+//	for page, err := range client.ImagesPaginated(ctx, params) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (c *Client) ImagesPaginated(ctx context.Context, params map[string]string, opts ...CallOption) iter.Seq2[ImagesPage, error] {
+	ctx = ctxOrBackground(ctx)
+	return func(yield func(ImagesPage, error) bool) {
+		page := 1
+		if n, err := strconv.Atoi(params["page"]); err == nil && n > 0 {
+			page = n
+		}
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(ImagesPage{}, err)
+				return
+			}
+			resp, err := c.ImagesContext(ctx, withParam(params, "page", strconv.Itoa(page)), opts...)
+			if err != nil {
+				yield(ImagesPage{}, err)
+				return
+			}
+			p := ImagesPage{Page: page, Images: asSlice(resp["images"]), Raw: resp}
+			p.Total, _ = toInt(firstValue(resp, "total", "total_count", "totalCount", "count"))
+			if n, ok := toInt(resp["page"]); ok && n > 0 {
+				p.Page = n
+			}
+			if !yield(p, nil) {
+				return
+			}
+			next, ok := defaultNextToken(resp)
+			if !ok || len(p.Images) == 0 {
+				return
+			}
+			n, err := strconv.Atoi(next)
+			if err != nil || n <= p.Page {
+				return
+			}
+			page = n
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package carsxe
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Call describes an API call as seen by an Interceptor.
+type Call struct {
+	// Endpoint is the API path without a leading slash, e.g. "specs".
+	Endpoint string
+	// Params holds the query parameters without the API key.
+	Params url.Values
+	// Request is the outgoing request. Interceptors may modify its headers,
+	// e.g. to sign it, or replace it with req.WithContext.
+	Request *http.Request
+}
+
+// CallHandler performs a call and returns its response. For non-2xx
+// responses both the response and an *APIError are returned.
+type CallHandler func(call *Call) (*Response, error)
+
+// Interceptor wraps every API call. Unlike the transport Middleware of
+// WithRoundTripper, it runs once per call, outside caching, rate limiting and
+// retries, and sees the endpoint name, parameters and the complete response,
+// which resp.Decode turns into any shape.
+type Interceptor func(next CallHandler) CallHandler
+
+// WithInterceptor adds interceptors around every call. They run in
+// registration order: the first one registered is the outermost. Calling it
+// again adds to the chain.
+func WithInterceptor(interceptors ...Interceptor) Option {
+	return func(c *Client) { c.interceptors = append(c.interceptors, interceptors...) }
+}
+
+// intercept runs the call through the interceptor chain, ending with do.
+func (c *Client) intercept(req *http.Request, endpoint string, do CallHandler) (*Response, error) {
+	if len(c.interceptors) == 0 {
+		return do(&Call{Endpoint: endpoint, Request: req})
+	}
+	h := do
+	for i := len(c.interceptors) - 1; i >= 0; i-- {
+		h = c.interceptors[i](h)
+	}
+	params := req.URL.Query()
+	params.Del("key")
+	return h(&Call{Endpoint: strings.Trim(endpoint, "/"), Params: params, Request: req})
+}
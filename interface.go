@@ -0,0 +1,51 @@
+package carsxe
+
+import (
+	"context"
+	"io"
+)
+
+// CarsXE is the set of endpoint methods of Client. Code that depends on it
+// instead of *Client can be given a fake in tests or a wrapper adding, say,
+// auditing; embed a CarsXE in a struct to override only some methods.
+type CarsXE interface {
+	Ping(ctx context.Context) error
+
+	SpecsContext(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error)
+	MarketValueContext(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error)
+	HistoryContext(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error)
+	RecallsContext(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error)
+	InternationalVINDecoderContext(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error)
+	PlateDecoderContext(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error)
+	PlateImageRecognitionContext(ctx context.Context, imageURL string, opts ...CallOption) (map[string]any, error)
+	VinOCRContext(ctx context.Context, imageURL string, opts ...CallOption) (map[string]any, error)
+	YearMakeModelContext(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error)
+	ImagesContext(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error)
+	ObdCodesDecoderContext(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error)
+	LienAndTheftContext(ctx context.Context, params map[string]string, opts ...CallOption) (map[string]any, error)
+
+	SpecsTyped(ctx context.Context, params map[string]string, opts ...CallOption) (*SpecsResult, error)
+	MarketValueTyped(ctx context.Context, params map[string]string, opts ...CallOption) (*MarketValueResult, error)
+	HistoryTyped(ctx context.Context, params map[string]string, opts ...CallOption) (*HistoryResult, error)
+	RecallsTyped(ctx context.Context, params map[string]string, opts ...CallOption) (*RecallsResult, error)
+	InternationalVINDecoderTyped(ctx context.Context, params map[string]string, opts ...CallOption) (*InternationalVINResult, error)
+	PlateDecoderTyped(ctx context.Context, q PlateQuery, opts ...CallOption) (*PlateResult, error)
+	PlateImageRecognitionTyped(ctx context.Context, imageURL string, opts ...CallOption) (*PlateRecognitionResult, error)
+	YearMakeModelTyped(ctx context.Context, params map[string]string, opts ...CallOption) (*YMMResult, error)
+	ImagesTyped(ctx context.Context, params map[string]string, opts ...CallOption) (*ImagesResult, error)
+	ObdCodesDecoderTyped(ctx context.Context, params map[string]string, opts ...CallOption) (*OBDResult, error)
+	LienAndTheftTyped(ctx context.Context, params map[string]string, opts ...CallOption) (*LienTheftResult, error)
+
+	VinOCRFromReader(ctx context.Context, r io.Reader, filename string, opts ...CallOption) (map[string]any, error)
+	VinOCRFromFile(ctx context.Context, path string, opts ...CallOption) (map[string]any, error)
+	PlateImageRecognitionFromReader(ctx context.Context, r io.Reader, filename string, opts ...CallOption) (map[string]any, error)
+	PlateImageRecognitionFromFile(ctx context.Context, path string, opts ...CallOption) (map[string]any, error)
+}
+
+var _ CarsXE = (*Client)(nil)
+
+// NewCarsXE is like New but returns the client as a CarsXE, for wiring into
+// code that depends on the interface.
+func NewCarsXE(apiKey string, opts ...Option) CarsXE {
+	return New(apiKey, opts...)
+}
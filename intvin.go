@@ -0,0 +1,53 @@
+package carsxe
+
+import "context"
+
+// InternationalVINResult is the typed form of an InternationalVINDecoder
+// response.
+type InternationalVINResult struct {
+	VIN          string
+	Make         string
+	Model        string
+	Year         int
+	Manufacturer string
+	Body         string
+	Series       string
+	FuelType     string
+	EngineSize   string
+	Transmission string
+	Drive        string
+	// Raw is the decoded response body.
+	Raw map[string]any
+}
+
+// InternationalVINDecoderTyped is like InternationalVINDecoder but honors
+// ctx, returns errors and decodes the response into an
+// InternationalVINResult.
+func (c *Client) InternationalVINDecoderTyped(ctx context.Context, params map[string]string, opts ...CallOption) (*InternationalVINResult, error) {
+	raw, err := c.InternationalVINDecoderContext(ctx, params, opts...)
+	if err != nil {
+		return nil, err
+	}
+	attrs := asMap(raw["attributes"])
+	if attrs == nil {
+		attrs = map[string]any{}
+	}
+	r := &InternationalVINResult{
+		VIN:          firstString(attrs, "vin"),
+		Make:         firstString(attrs, "make"),
+		Model:        firstString(attrs, "model"),
+		Manufacturer: firstString(attrs, "manufacturer"),
+		Body:         firstString(attrs, "body", "body_type"),
+		Series:       firstString(attrs, "series"),
+		FuelType:     firstString(attrs, "fuel_type"),
+		EngineSize:   firstString(attrs, "engine_size", "engine_displacement"),
+		Transmission: firstString(attrs, "transmission"),
+		Drive:        firstString(attrs, "drive", "drivetrain"),
+		Raw:          raw,
+	}
+	r.Year, _ = toInt(firstValue(attrs, "year", "model_year"))
+	if r.VIN == "" {
+		r.VIN = params["vin"]
+	}
+	return r, nil
+}
@@ -0,0 +1,185 @@
+package carsxe
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrAllKeysBenched is returned without contacting the API when every key
+// of a WithAPIKeys pool is benched after running out of quota.
+var ErrAllKeysBenched = errors.New("carsxe: all API keys are out of quota")
+
+// defaultBenchTime is how long a key that ran out of quota is benched when
+// the response does not say when to retry.
+const defaultBenchTime = time.Minute
+
+// RotationStrategy selects the key of a WithAPIKeys pool used for each
+// request.
+type RotationStrategy int
+
+const (
+	// RoundRobin uses the keys in turn.
+	RoundRobin RotationStrategy = iota
+	// QuotaAware uses the key with the most credits remaining, as last
+	// reported in the usage headers, trying keys with unknown usage first.
+	QuotaAware
+)
+
+// WithAPIKeys spreads requests over several API keys, chosen per attempt
+// according to strategy; the key passed to New is ignored. A key that gets a
+// 429 response, or a 402 or 403 mentioning its quota, is benched until the
+// response's Retry-After or quota reset time (a minute if neither is given)
+// and skipped meanwhile; with WithRetry, the retry of such a 429 goes out
+// with another key. Every key is masked in logs and errors. KeyStats reports
+// per-key usage.
+func WithAPIKeys(keys []string, strategy RotationStrategy) Option {
+	return func(c *Client) {
+		p := &keyPool{strategy: strategy}
+		for _, k := range keys {
+			if k = strings.TrimSpace(k); k != "" {
+				p.keys = append(p.keys, &poolKey{key: k, remaining: -1})
+			}
+		}
+		if len(p.keys) == 0 {
+			return
+		}
+		c.keys = p
+		c.apiKey = p.keys[0].key
+	}
+}
+
+// KeyStats is the usage of one key of a WithAPIKeys pool.
+type KeyStats struct {
+	// Index is the key's position in the slice given to WithAPIKeys.
+	Index int
+	// Suffix is the last four characters of the key, to tell keys apart
+	// without exposing them.
+	Suffix      string
+	Requests    int
+	RateLimited int
+	// CreditsRemaining is the last reported remaining quota, or -1 if the
+	// API has not reported it.
+	CreditsRemaining int
+	// BenchedUntil is set while the key is benched.
+	BenchedUntil time.Time
+}
+
+// KeyStats returns the usage of each key of the WithAPIKeys pool, or nil
+// when the client uses a single key.
+func (c *Client) KeyStats() []KeyStats {
+	if c.keys == nil {
+		return nil
+	}
+	p := c.keys
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	out := make([]KeyStats, len(p.keys))
+	for i, k := range p.keys {
+		out[i] = KeyStats{
+			Index:            i,
+			Suffix:           k.key[max(len(k.key)-4, 0):],
+			Requests:         k.requests,
+			RateLimited:      k.rateLimited,
+			CreditsRemaining: k.remaining,
+		}
+		if k.benchedUntil.After(now) {
+			out[i].BenchedUntil = k.benchedUntil
+		}
+	}
+	return out
+}
+
+type keyPool struct {
+	strategy RotationStrategy
+
+	mu   sync.Mutex
+	keys []*poolKey
+	next int
+}
+
+type poolKey struct {
+	key          string
+	requests     int
+	rateLimited  int
+	remaining    int
+	benchedUntil time.Time
+}
+
+// pick returns the key to use for the next attempt.
+func (p *keyPool) pick() (*poolKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := time.Now()
+	var best *poolKey
+	for i := range p.keys {
+		k := p.keys[(p.next+i)%len(p.keys)]
+		if k.benchedUntil.After(now) {
+			continue
+		}
+		if best == nil {
+			best = k
+			if p.strategy == RoundRobin {
+				break
+			}
+		} else if p.strategy == QuotaAware && best.remaining >= 0 && (k.remaining < 0 || k.remaining > best.remaining) {
+			best = k
+		}
+	}
+	if best == nil {
+		return nil, ErrAllKeysBenched
+	}
+	p.next = (p.next + 1) % len(p.keys)
+	best.requests++
+	return best, nil
+}
+
+// record updates k with the outcome of a request made with it.
+func (p *keyPool) record(k *poolKey, resp *Response, err error) {
+	if resp == nil {
+		return
+	}
+	now := time.Now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	u, hasUsage := parseUsage(resp.Header, now)
+	if hasUsage {
+		k.remaining = u.CreditsRemaining
+	}
+	if !quotaExhausted(resp, err) {
+		return
+	}
+	k.rateLimited++
+	until := now.Add(defaultBenchTime)
+	if d, ok := retryAfter(resp.Header); ok {
+		until = now.Add(d)
+	} else if !u.ResetAt.IsZero() {
+		until = u.ResetAt
+	}
+	k.benchedUntil = until
+}
+
+// quotaExhausted reports whether a response says its key is out of quota.
+func quotaExhausted(resp *Response, err error) bool {
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusPaymentRequired, http.StatusForbidden:
+		var apiErr *APIError
+		return errors.As(err, &apiErr) &&
+			strings.Contains(strings.ToLower(apiErr.Message+" "+apiErr.Body), "quota")
+	}
+	return false
+}
+
+// withKey returns req using k as its API key.
+func withKey(req *http.Request, k *poolKey) *http.Request {
+	req = req.Clone(req.Context())
+	q := req.URL.Query()
+	q.Set("key", k.key)
+	req.URL.RawQuery = q.Encode()
+	return req
+}
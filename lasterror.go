@@ -0,0 +1,63 @@
+package carsxe
+
+import (
+	"net/http"
+	"time"
+)
+
+// ErrorDetail captures everything known about a failed call, suitable for
+// attaching to a support ticket. The URL and Body are redacted like log
+// output; Err is the error the call returned, unchanged.
+type ErrorDetail struct {
+	Method     string
+	URL        string
+	Endpoint   string
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	Err        error
+	StartedAt  time.Time
+	// Duration spans every attempt, including waits between retries.
+	Duration time.Duration
+	// Attempts is the number of HTTP attempts made (see WithRetry).
+	Attempts int
+}
+
+// LastError returns the details of the most recent failed call, or nil if no
+// call has failed since the client was created or ClearLastError was called.
+// It is safe for concurrent use.
+func (c *Client) LastError() *ErrorDetail {
+	c.lastErrMu.Lock()
+	defer c.lastErrMu.Unlock()
+	return c.lastErr
+}
+
+// ClearLastError forgets the recorded failure.
+func (c *Client) ClearLastError() {
+	c.lastErrMu.Lock()
+	c.lastErr = nil
+	c.lastErrMu.Unlock()
+}
+
+// recordError stores the details of a failed call.
+func (c *Client) recordError(req *http.Request, endpoint string, resp *Response, start time.Time, d time.Duration, attempts int, err error) {
+	detail := &ErrorDetail{
+		Method:    req.Method,
+		URL:       c.redactedURL(req.URL),
+		Endpoint:  endpoint,
+		Err:       err,
+		StartedAt: start,
+		Duration:  d,
+		Attempts:  attempts,
+	}
+	if resp != nil {
+		detail.StatusCode = resp.StatusCode
+		detail.Header = resp.Header.Clone()
+		if resp.Body != nil {
+			detail.Body = []byte(c.redactText(req, string(resp.Body)))
+		}
+	}
+	c.lastErrMu.Lock()
+	c.lastErr = detail
+	c.lastErrMu.Unlock()
+}
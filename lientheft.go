@@ -0,0 +1,62 @@
+package carsxe
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// LienTheftResult is the typed form of a LienAndTheft response.
+type LienTheftResult struct {
+	VIN string
+	// HasLien and HasTheft report whether any record is a lien or a theft
+	// record respectively.
+	HasLien  bool
+	HasTheft bool
+	Records  []LienTheftRecord
+	// Raw is the decoded response body.
+	Raw map[string]any
+}
+
+// LienTheftRecord is a single lien or theft record.
+type LienTheftRecord struct {
+	Type string
+	// Date is zero when the record carries no recognizable date.
+	Date        time.Time
+	Description string
+	Raw         map[string]any
+}
+
+// LienAndTheftTyped is like LienAndTheft but honors ctx, returns errors and
+// decodes the response into a LienTheftResult.
+func (c *Client) LienAndTheftTyped(ctx context.Context, params map[string]string, opts ...CallOption) (*LienTheftResult, error) {
+	raw, err := c.LienAndTheftContext(ctx, params, opts...)
+	if err != nil {
+		return nil, err
+	}
+	data := raw
+	if m := asMap(raw["data"]); m != nil {
+		data = m
+	}
+	r := &LienTheftResult{VIN: firstString(data, "vin"), Raw: raw}
+	if r.VIN == "" {
+		r.VIN = params["vin"]
+	}
+	for _, item := range asSlice(firstValue(data, "events", "records")) {
+		m := asMap(item)
+		if m == nil {
+			continue
+		}
+		rec := LienTheftRecord{
+			Type:        firstString(m, "type", "eventType", "event_type"),
+			Description: firstString(m, "description", "details"),
+			Raw:         m,
+		}
+		rec.Date, _ = toTime(firstValue(m, "date", "eventDate", "event_date"))
+		kind := strings.ToLower(rec.Type)
+		r.HasLien = r.HasLien || strings.Contains(kind, "lien")
+		r.HasTheft = r.HasTheft || strings.Contains(kind, "theft") || strings.Contains(kind, "stolen")
+		r.Records = append(r.Records, rec)
+	}
+	return r, nil
+}
@@ -0,0 +1,135 @@
+package carsxe
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Attribute keys used by the slog integration. They are stable across
+// releases so they can be relied on when building dashboards and alerts.
+const (
+	LogKeyEndpoint = "endpoint"
+	LogKeyMethod   = "method"
+	LogKeyStatus   = "status"
+	LogKeyDuration = "duration"
+	LogKeyError    = "error"
+	LogKeyParams   = "params"
+	LogKeyAttempt  = "attempt"
+	LogKeyRetryIn  = "retry_in"
+
+	LogKeyRequestBody  = "request_body"
+	LogKeyResponseBody = "response_body"
+)
+
+// WithSlog enables structured logging of every API call through l.
+// Successful calls are logged at Debug, failed attempts that WithRetry will
+// retry at Warn and failed calls at Error; WithLogLevels changes these.
+// Query parameters are logged as a group with the API key removed and VINs
+// redacted when WithVINRedaction is set.
+func WithSlog(l *slog.Logger) Option {
+	return func(c *Client) { c.logger = l }
+}
+
+// WithLogger is an alias for WithSlog.
+func WithLogger(l *slog.Logger) Option { return WithSlog(l) }
+
+// LogLevels sets the level each kind of call outcome is logged at.
+type LogLevels struct {
+	Success slog.Level
+	Retry   slog.Level
+	Failure slog.Level
+}
+
+var defaultLogLevels = LogLevels{Success: slog.LevelDebug, Retry: slog.LevelWarn, Failure: slog.LevelError}
+
+// WithLogLevels replaces the default levels of WithSlog, e.g. to log
+// successful calls at Info.
+func WithLogLevels(l LogLevels) Option {
+	return func(c *Client) { c.logLevels = l }
+}
+
+// WithBodyLogging adds request and response bodies, truncated to maxBytes
+// and with the API key masked, to log records when the logger is enabled
+// for Debug. Under WithVINRedaction the request's VINs are masked too.
+// Streamed image uploads are not logged. A maxBytes of zero or less turns
+// body logging off.
+func WithBodyLogging(maxBytes int) Option {
+	return func(c *Client) { c.logBodies = max(maxBytes, 0) }
+}
+
+// logCall records the outcome of a single attempt of an API call.
+func (c *Client) logCall(req *http.Request, endpoint string, resp *Response, d time.Duration, attempt int, retryIn time.Duration, retry bool, err error) {
+	if c.logger == nil {
+		return
+	}
+	level := c.logLevels.Success
+	msg := "carsxe request succeeded"
+	switch {
+	case retry:
+		level = c.logLevels.Retry
+		msg = "carsxe request failed, retrying"
+	case err != nil:
+		level = c.logLevels.Failure
+		msg = "carsxe request failed"
+	}
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+	ctx := req.Context()
+	if !c.logger.Enabled(ctx, level) {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.String(LogKeyEndpoint, strings.TrimLeft(endpoint, "/")),
+		slog.String(LogKeyMethod, req.Method),
+		slog.Int(LogKeyStatus, status),
+		slog.Duration(LogKeyDuration, d),
+		c.paramsAttr(req),
+	}
+	if attempt > 1 || retry {
+		attrs = append(attrs, slog.Int(LogKeyAttempt, attempt))
+	}
+	if retry {
+		attrs = append(attrs, slog.Duration(LogKeyRetryIn, retryIn))
+	}
+	if err != nil {
+		attrs = append(attrs, slog.String(LogKeyError, c.redactText(req, err.Error())))
+	}
+	if c.logBodies > 0 && c.logger.Enabled(ctx, slog.LevelDebug) {
+		if req.GetBody != nil {
+			if body, err := req.GetBody(); err == nil {
+				b, _ := io.ReadAll(io.LimitReader(body, int64(c.logBodies)+1))
+				body.Close()
+				attrs = append(attrs, slog.String(LogKeyRequestBody, c.logBody(req, b)))
+			}
+		}
+		if resp != nil {
+			attrs = append(attrs, slog.String(LogKeyResponseBody, c.logBody(req, resp.Body)))
+		}
+	}
+	c.logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// logBody truncates b, a body of req or its response, for logging, and
+// redacts it with redactText.
+func (c *Client) logBody(req *http.Request, b []byte) string {
+	if len(b) > c.logBodies {
+		return c.redactText(req, strings.ToValidUTF8(string(b[:c.logBodies]), "")) + "...(truncated)"
+	}
+	return c.redactText(req, string(b))
+}
+
+// paramsAttr returns the request's query parameters as a log group, redacted
+// with redactQuery.
+func (c *Client) paramsAttr(req *http.Request) slog.Attr {
+	q := c.redactQuery(req.URL.Query())
+	attrs := make([]any, 0, len(q))
+	for k, v := range q {
+		attrs = append(attrs, slog.String(k, strings.Join(v, ",")))
+	}
+	return slog.Group(LogKeyParams, attrs...)
+}
@@ -2,13 +2,20 @@ package carsxe
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
+
+	"golang.org/x/time/rate"
 )
 
 // Client is a minimal CarsXE API client that works with simple key/value maps.
@@ -17,6 +24,75 @@ type Client struct {
 	baseURL    string
 	source     string
 	httpClient *http.Client
+	baseHTTP   *http.Client // httpClient before buildTransport, for Clone
+	logger     *slog.Logger
+	logLevels  LogLevels
+	logBodies  int
+
+	uploadBudget   *byteBudget
+	maxUploadSize  int64
+	maxRespBytes   int64
+	maxJSONDepth   int
+	errBodyLimit   int
+	limiter        *rate.Limiter
+	limiterGate    *prioritySemaphore
+	concurrency    *prioritySemaphore
+	cache          Cache
+	cacheTTL       time.Duration
+	staleWindow    time.Duration
+	endpointStale  map[string]time.Duration
+	revalidating   *revalidator
+	endpointTTLs   map[string]time.Duration
+	metrics        []Metrics
+	middleware     []Middleware
+	vinRedactor    Redactor
+	serial         *prioritySemaphore
+	enumDecoding   bool
+	units          UnitSystem
+	lenient        bool
+	useNumber      bool
+	strictDecoding bool
+	validateVINs   bool
+	validateParams bool
+	unwrapEnvelope bool
+	offlineOBD     bool
+	dryRun         bool
+	flights        *flightGroup
+	keys           *keyPool
+	auth           Authenticator
+	auditSink      AuditSink
+	credits        *creditMeter
+	allowed        map[string]bool
+	routes         map[string]string
+	apiVersions    map[string]string
+	breaker        *circuitBreaker
+
+	maxRetries     int
+	retryBaseDelay time.Duration
+	retryPolicy    RetryPolicy
+	hedgeDelay     time.Duration
+	maxHedges      int
+
+	headers       http.Header
+	interceptors  []Interceptor
+	userAgent     string
+	requestHooks  []func(*http.Request)
+	responseHooks []func(*http.Response, time.Duration)
+
+	dialRetries           int
+	dialDelay             time.Duration
+	responseHeaderTimeout time.Duration
+	proxySet              bool
+	proxyURL              *url.URL
+	tlsConfig             *tls.Config
+	bodyReadTimeout       time.Duration
+	verifyChecksums       bool
+
+	lastErrMu sync.Mutex
+	lastErr   *ErrorDetail
+	usageMu   sync.Mutex
+	usage     Usage
+	rateLimit RateLimitState
 }
 
 // Option configures a Client instance.
@@ -32,6 +108,16 @@ func WithHTTPClient(h *http.Client) Option {
 	return func(c *Client) { c.httpClient = h }
 }
 
+// WithTimeout sets the overall timeout of each HTTP request (default 15s).
+// The *http.Client passed to WithHTTPClient is not modified.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		hc := *c.httpClient
+		hc.Timeout = d
+		c.httpClient = &hc
+	}
+}
+
 // WithSource changes the default "source" query parameter (default: "go").
 func WithSource(src string) Option {
 	return func(c *Client) { c.source = src }
@@ -40,9 +126,16 @@ func WithSource(src string) Option {
 // New creates a new CarsXE client.
 func New(apiKey string, opts ...Option) *Client {
 	c := &Client{
-		apiKey:  apiKey,
-		baseURL: "https://api.carsxe.com",
-		source:  "go",
+		apiKey:        apiKey,
+		baseURL:       "https://api.carsxe.com",
+		source:        "go",
+		maxJSONDepth:  defaultMaxJSONDepth,
+		errBodyLimit:  maxErrorBodyLen,
+		credits:       newCreditMeter(),
+		maxUploadSize: defaultMaxUploadSize,
+		maxRespBytes:  defaultMaxResponseBytes,
+		cacheTTL:      defaultCacheTTL,
+		logLevels:     defaultLogLevels,
 		httpClient: &http.Client{
 			Timeout: 15 * time.Second,
 		},
@@ -50,148 +143,573 @@ func New(apiKey string, opts ...Option) *Client {
 	for _, o := range opts {
 		o(c)
 	}
+	c.baseHTTP = c.httpClient
+	c.buildTransport()
 	return c
 }
 
-// buildURL builds a full URL with provided raw map params (no reflection).
-func (c *Client) buildURL(endpoint string, params map[string]string) string {
-	u, err := url.Parse(c.baseURL + "/" + strings.TrimLeft(endpoint, "/"))
+// buildURL builds a full URL with the given query params. The "key" and
+// "source" params are always the client's own and appear exactly once, even
+// if params contains them; empty values are dropped. Under WithAuthenticator
+// no "key" is added.
+func (c *Client) buildURL(endpoint string, params url.Values) (string, error) {
+	u, err := url.Parse(c.endpointURL(endpoint))
 	if err != nil {
-		panic(fmt.Sprintf("Failed to parse URL: %v", err))
+		return "", fmt.Errorf("Failed to parse URL: %w", err)
 	}
 	q := u.Query()
-	q.Set("key", c.apiKey)
+	for k, vs := range params {
+		for _, v := range vs {
+			if v != "" {
+				q.Add(k, v)
+			}
+		}
+	}
+	if c.auth == nil {
+		q.Set("key", c.apiKey)
+	} else {
+		q.Del("key")
+	}
 	q.Set("source", c.source)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// valuesOf converts single-value params to url.Values.
+func valuesOf(params map[string]string) url.Values {
+	q := make(url.Values, len(params))
 	for k, v := range params {
-		if v != "" {
-			q.Add(k, v)
+		q.Set(k, v)
+	}
+	return q
+}
+
+// ctxOrBackground returns ctx, or context.Background() if ctx is nil, so
+// that every method accepts a nil context.
+func ctxOrBackground(ctx context.Context) context.Context {
+	if ctx == nil {
+		return context.Background()
+	}
+	return ctx
+}
+
+// newRequest builds an API request for endpoint bound to ctx.
+func (c *Client) newRequest(ctx context.Context, method, endpoint string, params url.Values, body io.Reader) (*http.Request, error) {
+	ctx = ctxOrBackground(ctx)
+	urlStr, err := c.buildURL(endpoint, params)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, urlStr, body)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create request: %w", err)
+	}
+	return req, nil
+}
+
+// doRequest executes the HTTP request and reads the full response body.
+// Non-2xx responses are reported as *APIError (or *ValidationError) along
+// with the response. GET responses are served from and stored in the cache
+// when one is configured.
+func (c *Client) doRequest(req *http.Request, endpoint string, co *callOptions) (*Response, error) {
+	applyQuery(req, co)
+	return c.intercept(req, endpoint, func(call *Call) (*Response, error) {
+		return c.execute(call.Request, endpoint, co)
+	})
+}
+
+// send performs a call: cache lookup, limits, attempts and bookkeeping.
+func (c *Client) send(req *http.Request, endpoint string, co *callOptions) (*Response, error) {
+	req, cancel, err := c.beginCall(req, endpoint, co)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+
+	cacheKey := ""
+	ttl, cacheable := c.cacheTTLFor(endpoint)
+	if c.cache != nil && req.Method == http.MethodGet && !co.noCache && cacheable {
+		cacheKey = cacheKeyFor(c.endpointPath(endpoint), req.URL)
+	}
+	if cacheKey != "" && !co.refreshCache {
+		if val, ok := c.cache.Get(cacheKey); ok {
+			body, stale := unwrapStale(val)
+			if !stale || c.staleWindowFor(endpoint) > 0 {
+				c.observeCache(endpoint, true)
+				resp := &Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: body, FromCache: true, Stale: stale}
+				if stale {
+					c.revalidate(req, endpoint, co, cacheKey)
+				}
+				co.capture(resp)
+				return resp, nil
+			}
 		}
+		c.observeCache(endpoint, false)
 	}
-	u.RawQuery = q.Encode()
-	return u.String()
+
+	release, err := c.acquireSerial(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	start := time.Now()
+	var (
+		resp     *Response
+		attempts int
+	)
+	for {
+		a, slotErr := c.reserveAttempt(req, endpoint, co)
+		if slotErr != nil {
+			if attempts == 0 {
+				return nil, slotErr
+			}
+			break // keep the previous attempt's outcome
+		}
+		req = a.req
+		attempts++
+		sent := time.Now()
+		resp, err = c.hedgedRoundTrip(a, endpoint, co)
+		d := time.Since(sent)
+
+		wait, retry := c.retryDelay(req, co, resp, err, attempts)
+		c.observeAttempt(req, endpoint, resp, d, attempts, wait, retry, err)
+		if !retry || sleepContext(req.Context(), wait) != nil {
+			break
+		}
+		next, rerr := rewindRequest(req)
+		if rerr != nil {
+			break
+		}
+		req = next
+	}
+	if err != nil && attempts > 1 {
+		err = &RetryError{Attempts: attempts, Err: err}
+	}
+	if err == nil && cacheKey != "" {
+		if window := c.staleWindowFor(endpoint); window > 0 && ttl > 0 {
+			c.cache.Set(cacheKey, wrapStale(resp.Body, time.Now().Add(ttl)), ttl+window)
+		} else {
+			c.cache.Set(cacheKey, resp.Body, ttl)
+		}
+	}
+	c.endCall(req, endpoint, co, resp, start, attempts, err)
+	return resp, err
+}
+
+// beginCall runs the steps every call takes before its first attempt: the
+// WithAllowedEndpoints check, headers, WithDryRun and the per-call timeout.
+// cancel releases the timeout once the call is done.
+func (c *Client) beginCall(req *http.Request, endpoint string, co *callOptions) (_ *http.Request, cancel context.CancelFunc, err error) {
+	if err := c.checkAllowed(endpoint); err != nil {
+		return nil, nil, err
+	}
+	c.applyHeaders(req, co)
+	if c.dryRun {
+		return nil, nil, c.newDryRunError(req)
+	}
+	if co.timeout <= 0 {
+		return req, func() {}, nil
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), co.timeout)
+	return req.WithContext(ctx), cancel, nil
+}
+
+// acquireSerial waits for the WithSerial queue, if any.
+func (c *Client) acquireSerial(ctx context.Context) (release func(), err error) {
+	if c.serial == nil {
+		return func() {}, nil
+	}
+	if err := c.serial.acquire(ctx, PriorityNormal); err != nil {
+		return nil, err
+	}
+	return c.serial.release, nil
+}
+
+// observeAttempt reports one attempt of a call, the attempts-th, to usage
+// tracking, the logger and metrics.
+func (c *Client) observeAttempt(req *http.Request, endpoint string, resp *Response, d time.Duration, attempts int, wait time.Duration, retry bool, err error) {
+	status := 0
+	if resp != nil {
+		resp.Attempts = attempts
+		status = resp.StatusCode
+		c.recordUsage(resp.Header)
+	}
+	c.logCall(req, endpoint, resp, d, attempts, wait, retry, err)
+	c.observeRequest(endpoint, status, d, err)
+}
+
+// endCall records the outcome of a completed call for LastError, the audit
+// sink and WithResponse.
+func (c *Client) endCall(req *http.Request, endpoint string, co *callOptions, resp *Response, start time.Time, attempts int, err error) {
+	if err != nil {
+		c.recordError(req, strings.TrimLeft(endpoint, "/"), resp, start, time.Since(start), attempts, err)
+	}
+	c.audit(req, endpoint, resp, start, attempts, err)
+	co.capture(resp)
+}
+
+// attempt holds what reserveAttempt set aside for one HTTP attempt.
+type attempt struct {
+	c       *Client
+	req     *http.Request // the request with the attempt's pool key
+	signed  *http.Request // req as sent, after the Authenticator
+	key     *poolKey
+	settle  func(ok bool)
+	done    func(err error)
+	release func()
+}
+
+// reserveAttempt checks the budget and circuit breaker, then waits for a
+// concurrency slot and rate-limit token and picks a pool key for one attempt
+// of req. Its finish method must be called once the attempt completes.
+func (c *Client) reserveAttempt(req *http.Request, endpoint string, co *callOptions) (*attempt, error) {
+	settle, err := c.credits.reserve(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	a := &attempt{c: c, req: req, signed: req, settle: settle}
+	if a.done, err = c.breaker.allow(); err != nil {
+		settle(false)
+		return nil, err
+	}
+	if a.release, err = c.acquireSlot(req.Context(), co.priority); err != nil {
+		a.abort()
+		return nil, err
+	}
+	if c.keys != nil {
+		if a.key, err = c.keys.pick(); err != nil {
+			a.release()
+			a.abort()
+			return nil, err
+		}
+		a.req = withKey(req, a.key)
+		a.signed = a.req
+	}
+	if c.auth != nil {
+		if a.signed, err = c.authenticate(a.req); err != nil {
+			a.release()
+			a.abort()
+			return nil, err
+		}
+	}
+	return a, nil
 }
 
-// doRequest executes the HTTP request and decodes JSON into a generic map.
-func (c *Client) doRequest(req *http.Request) map[string]any {
-	resp, err := c.httpClient.Do(req)
+// abort gives back the budget and breaker slot of an attempt never sent.
+func (a *attempt) abort() {
+	a.done(errNotSent)
+	a.settle(false)
+}
+
+// finishCancelled finishes an attempt that was sent, then cancelled because
+// another copy of a hedged request answered first. The API may still have
+// billed it, so its credits are kept.
+func (a *attempt) finishCancelled(err error) {
+	a.release()
+	a.done(err)
+	a.settle(true)
+}
+
+// finish releases the attempt's slot and records its outcome.
+func (a *attempt) finish(resp *Response, err error) {
+	a.release()
+	a.done(err)
+	a.settle(err == nil)
+	if a.key != nil {
+		a.c.keys.record(a.key, resp, err)
+	}
+}
+
+// roundTrip sends req and reads the body, returning the response even when
+// the status is not 2xx so callers can report on failures.
+func (c *Client) roundTrip(req *http.Request, endpoint string) (*Response, error) {
+	req, startBodyTimer, stopBodyTimer := c.bodyDeadline(req)
+	if ae := acceptEncoding(); ae != "" && req.Header.Get("Accept-Encoding") == "" {
+		req.Header.Set("Accept-Encoding", ae)
+	}
+	c.runRequestHooks(req)
+	start := time.Now()
+	httpResp, err := c.httpClient.Do(req)
 	if err != nil {
-		panic(fmt.Sprintf("HTTP request failed: %v", err))
+		stopBodyTimer()
+		c.runResponseHooks(req, nil, nil, time.Since(start))
+		return nil, fmt.Errorf("HTTP request failed: %w", c.sanitizeError(err))
 	}
-	defer resp.Body.Close()
+	defer httpResp.Body.Close()
 
-	bodyBytes, err := io.ReadAll(resp.Body)
+	resp := &Response{StatusCode: httpResp.StatusCode, Header: httpResp.Header}
+	startBodyTimer()
+	body, decompressed, err := responseBody(httpResp)
+	if err == nil {
+		resp.Body, err = c.readBody(httpResp.Body, body)
+	}
+	timedOut := stopBodyTimer()
+	if len(c.responseHooks) > 0 {
+		c.runResponseHooks(req, httpResp, resp.Body, time.Since(start))
+	}
+	if timedOut {
+		return resp, fmt.Errorf("%w after %s", ErrBodyReadTimeout, c.bodyReadTimeout)
+	}
 	if err != nil {
-		panic(fmt.Sprintf("Failed to read response body: %v", err))
+		return resp, err
+	}
+	if c.verifyChecksums && !decompressed {
+		if err := verifyChecksum(httpResp, resp.Body); err != nil {
+			return resp, err
+		}
+	}
+	ctErr := c.checkContentType(httpResp.StatusCode, httpResp.Header.Get("Content-Type"), resp.Body)
+	if ctErr != nil && httpResp.StatusCode >= 200 && httpResp.StatusCode < 300 {
+		return resp, ctErr
+	}
+	if err := checkJSONDepth(resp.Body, c.maxJSONDepth); err != nil {
+		return resp, err
 	}
 
-	if len(bodyBytes) == 0 {
-		return map[string]any{}
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 || c.unwrapEnvelope {
+		return resp, c.responseError(req, endpoint, httpResp, resp.Body, ctErr)
 	}
+	return resp, nil
+}
 
-	var out map[string]any
-	if err := json.Unmarshal(bodyBytes, &out); err != nil {
-		panic(fmt.Sprintf("Failed to decode JSON: %v (body=%s)", err, string(bodyBytes)))
+// responseError returns the error reported by a non-2xx response, or by a
+// failure envelope under WithEnvelopeUnwrapping, with the given body.
+func (c *Client) responseError(req *http.Request, endpoint string, httpResp *http.Response, body []byte, ctErr *ContentTypeError) error {
+	decoded, _ := c.decodeMap(body)
+	err := newStatusError(endpoint, httpResp.StatusCode, body, decoded)
+	if err == nil && c.unwrapEnvelope {
+		err = newEnvelopeError(endpoint, httpResp.StatusCode, body, decoded)
+	}
+	if err == nil {
+		return nil
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		apiErr.RequestID = requestID(httpResp.Header)
+		apiErr.bodyLimit = c.errBodyLimit
+		if ctErr != nil {
+			apiErr.cause = ctErr
+			if apiErr.Message == "" {
+				apiErr.Message = ctErr.summary()
+			}
+		}
+	}
+	attachWMI(err, req.URL.Query().Get("vin"))
+	return c.sanitizeError(err)
+}
+
+// decodeMap decodes a JSON object body into a generic map. An empty body
+// decodes to an empty map.
+func decodeMap(body []byte) (map[string]any, error) {
+	return decodeMapNumbers(body, false)
+}
+
+// decodeMap is the package-level decodeMap, with numbers as json.Number under
+// WithJSONNumbers.
+func (c *Client) decodeMap(body []byte) (map[string]any, error) {
+	return decodeMapNumbers(body, c.useNumber)
+}
+
+func decodeMapNumbers(body []byte, useNumber bool) (map[string]any, error) {
+	out := map[string]any{}
+	if len(body) == 0 {
+		return out, nil
+	}
+	var err error
+	if useNumber {
+		dec := json.NewDecoder(bytes.NewReader(body))
+		dec.UseNumber()
+		if err = dec.Decode(&out); err == nil && dec.More() {
+			err = errors.New("invalid character after top-level value")
+		}
+	} else {
+		err = json.Unmarshal(body, &out)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decode JSON: %w (body=%s)", err, truncate(string(body), maxErrorBodyLen))
+	}
+	return out, nil
+}
+
+// must panics with err's message when err is non-nil, preserving the
+// panicking behavior of the map-based convenience methods. JSON error
+// payloads from non-2xx responses are returned as-is, as they always were.
+func must(out map[string]any, err error) map[string]any {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.decoded != nil {
+		return apiErr.decoded
+	}
+	if err != nil {
+		panic(err.Error())
 	}
 	return out
 }
 
-// Get performs a generic GET request to any endpoint with query params.
-func (c *Client) Get(endpoint string, params map[string]string) map[string]any {
-	if params == nil {
-		params = map[string]string{}
+// GetRaw performs a GET request bound to ctx and returns the response with
+// its metadata and undecoded body.
+func (c *Client) GetRaw(ctx context.Context, endpoint string, params map[string]string, opts ...CallOption) (*Response, error) {
+	return c.getRaw(ctx, endpoint, valuesOf(params), opts)
+}
+
+// GetStream performs a GET request bound to ctx and copies the undecoded
+// JSON body of a successful response to w, skipping the map[string]any
+// decoding of GetContext. The body is read in full before anything is
+// written, so retries, caching and WithMaxResponseBytes still apply and w
+// never receives a partial or failed response.
+func (c *Client) GetStream(ctx context.Context, endpoint string, params map[string]string, w io.Writer, opts ...CallOption) error {
+	resp, err := c.GetRaw(ctx, endpoint, params, opts...)
+	if err != nil {
+		return err
 	}
-	urlStr := c.buildURL(endpoint, params)
-	req, err := http.NewRequest(http.MethodGet, urlStr, nil)
+	_, err = w.Write(resp.Body)
+	return err
+}
+
+// getRaw is GetRaw for multi-value params.
+func (c *Client) getRaw(ctx context.Context, endpoint string, params url.Values, opts []CallOption) (*Response, error) {
+	if err := c.checkParams(endpoint, params); err != nil {
+		return nil, err
+	}
+	req, err := c.newRequest(ctx, http.MethodGet, endpoint, params, nil)
 	if err != nil {
-		panic(fmt.Sprintf("Failed to create request: %v", err))
+		return nil, err
 	}
-	return c.doRequest(req)
+	return c.doRequest(req, endpoint, newCallOptions(opts))
 }
 
-// postJSON performs a POST with a JSON body (used for image-based endpoints).
-func (c *Client) postJSON(endpoint string, body any) map[string]any {
-	urlStr := c.buildURL(endpoint, nil)
+// GetContext performs a generic GET request bound to ctx, returning errors
+// instead of panicking.
+func (c *Client) GetContext(ctx context.Context, endpoint string, params map[string]string, opts ...CallOption) (map[string]any, error) {
+	resp, err := c.GetRaw(ctx, endpoint, params, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return c.decodeResult(resp.Body)
+}
+
+// GetValues is like GetContext but takes url.Values, so a parameter can be
+// repeated (option=a&option=b).
+func (c *Client) GetValues(ctx context.Context, endpoint string, params url.Values, opts ...CallOption) (map[string]any, error) {
+	resp, err := c.getRaw(ctx, endpoint, params, opts)
+	if err != nil {
+		return nil, err
+	}
+	return c.decodeResult(resp.Body)
+}
+
+// Get performs a generic GET request to any endpoint with query params.
+func (c *Client) Get(endpoint string, params map[string]string) map[string]any {
+	return must(c.GetContext(context.Background(), endpoint, params))
+}
+
+// postJSONContext performs a POST with a JSON body bound to ctx.
+func (c *Client) postJSONContext(ctx context.Context, endpoint string, body any, opts ...CallOption) (map[string]any, error) {
+	ctx = ctxOrBackground(ctx)
 	var buf bytes.Buffer
 	if body != nil {
 		if err := json.NewEncoder(&buf).Encode(body); err != nil {
-			panic(fmt.Sprintf("Failed to encode JSON body: %v", err))
+			return nil, fmt.Errorf("Failed to encode JSON body: %w", err)
 		}
 	}
-	req, err := http.NewRequest(http.MethodPost, urlStr, &buf)
+	req, err := c.newRequest(ctx, http.MethodPost, endpoint, nil, &buf)
 	if err != nil {
-		panic(fmt.Sprintf("Failed to create request: %v", err))
+		return nil, err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	return c.doRequest(req)
+	if c.uploadBudget != nil {
+		n, err := c.uploadBudget.acquire(ctx, int64(buf.Len()))
+		if err != nil {
+			return nil, err
+		}
+		defer c.uploadBudget.release(n)
+	}
+	resp, err := c.doRequest(req, endpoint, newCallOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+	return c.decodeResult(resp.Body)
+}
+
+// postJSON performs a POST with a JSON body (used for image-based endpoints).
+func (c *Client) postJSON(endpoint string, body any) map[string]any {
+	return must(c.postJSONContext(context.Background(), endpoint, body))
+}
+
+// withParam returns a copy of params with key set to value, leaving the
+// caller's map untouched.
+func withParam(params map[string]string, key, value string) map[string]string {
+	out := make(map[string]string, len(params)+1)
+	for k, v := range params {
+		out[k] = v
+	}
+	out[key] = value
+	return out
 }
 
 /*
 Convenience methods mirroring the TypeScript SDK.
-All of these simply pass through to Get() or postJSON() with map[string]string.
+All of these simply pass through to the context-aware variants in
+endpoints.go, panicking on errors.
 You can remove these if you prefer only the generic Get().
 */
 
 // Specs => GET /specs (vin required; deepdata, disableIntVINDecoding optional)
 func (c *Client) Specs(params map[string]string) map[string]any {
-	return c.Get("specs", params)
+	return must(c.SpecsContext(context.Background(), params))
 }
 
 // MarketValue => GET /v2/marketvalue (vin)
 func (c *Client) MarketValue(params map[string]string) map[string]any {
-	return c.Get("v2/marketvalue", params)
+	return must(c.MarketValueContext(context.Background(), params))
 }
 
 // History => GET /history (vin)
 func (c *Client) History(params map[string]string) map[string]any {
-	return c.Get("history", params)
+	return must(c.HistoryContext(context.Background(), params))
 }
 
 // Recalls => GET /v1/recalls (vin)
 func (c *Client) Recalls(params map[string]string) map[string]any {
-	return c.Get("v1/recalls", params)
+	return must(c.RecallsContext(context.Background(), params))
 }
 
 // InternationalVINDecoder => GET /v1/international-vin-decoder (vin)
 func (c *Client) InternationalVINDecoder(params map[string]string) map[string]any {
-	return c.Get("v1/international-vin-decoder", params)
+	return must(c.InternationalVINDecoderContext(context.Background(), params))
 }
 
 // PlateDecoder => GET /v2/platedecoder (plate, country, state?, district?)
+// The plate is normalized with NormalizePlate before it is sent.
 func (c *Client) PlateDecoder(params map[string]string) map[string]any {
-	return c.Get("v2/platedecoder", params)
+	return must(c.PlateDecoderContext(context.Background(), params))
 }
 
 // PlateImageRecognition => POST /platerecognition with JSON {"image": "<url>"}
 func (c *Client) PlateImageRecognition(imageURL string) map[string]any {
-	if strings.TrimSpace(imageURL) == "" {
-		panic("image URL required")
-	}
-	return c.postJSON("platerecognition", map[string]string{"image": imageURL})
+	return must(c.PlateImageRecognitionContext(context.Background(), imageURL))
 }
 
 // VinOCR => POST /v1/vinocr with JSON {"image": "<url>"}
 func (c *Client) VinOCR(imageURL string) map[string]any {
-	if strings.TrimSpace(imageURL) == "" {
-		panic("image URL required")
-	}
-	return c.postJSON("v1/vinocr", map[string]string{"image": imageURL})
+	return must(c.VinOCRContext(context.Background(), imageURL))
 }
 
 // YearMakeModel => GET /v1/ymm (year, make, model, trim?)
 func (c *Client) YearMakeModel(params map[string]string) map[string]any {
-	return c.Get("v1/ymm", params)
+	return must(c.YearMakeModelContext(context.Background(), params))
 }
 
 // Images => GET /images (make, model, optional year, trim, color, etc.)
 func (c *Client) Images(params map[string]string) map[string]any {
-	return c.Get("images", params)
+	return must(c.ImagesContext(context.Background(), params))
 }
 
 // ObdCodesDecoder => GET /obdcodesdecoder (code)
 func (c *Client) ObdCodesDecoder(params map[string]string) map[string]any {
-	return c.Get("obdcodesdecoder", params)
+	return must(c.ObdCodesDecoderContext(context.Background(), params))
 }
 
-func(c *Client) LienAndTheft(params map[string]string) map[string]any {
-	return c.Get("/v1/lien-theft", params)
-}
\ No newline at end of file
+// LienAndTheft => GET /v1/lien-theft (vin)
+func (c *Client) LienAndTheft(params map[string]string) map[string]any {
+	return must(c.LienAndTheftContext(context.Background(), params))
+}
@@ -0,0 +1,116 @@
+package carsxe
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// MarketValueResult is the typed form of a MarketValue response. Values are
+// in US dollars; fields the API did not return are left at zero.
+type MarketValueResult struct {
+	VIN            string
+	Retail         float64
+	TradeIn        float64
+	RoughTradeIn   float64
+	AverageTradeIn float64
+	LoanValue      float64
+	MSRP           float64
+	Mileage        int
+	State          string
+	// Raw is the decoded response body.
+	Raw map[string]any
+}
+
+// MarketValueTyped is like MarketValue but honors ctx, returns errors and
+// decodes the response into a MarketValueResult.
+func (c *Client) MarketValueTyped(ctx context.Context, params map[string]string, opts ...CallOption) (*MarketValueResult, error) {
+	raw, err := c.MarketValueContext(ctx, params, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return newMarketValueResult(params["vin"], raw), nil
+}
+
+// newMarketValueResult maps a MarketValue response onto MarketValueResult.
+func newMarketValueResult(vin string, raw map[string]any) *MarketValueResult {
+	data := raw
+	if m := asMap(raw["data"]); m != nil {
+		data = m
+	}
+	r := &MarketValueResult{
+		VIN:   firstString(data, "vin"),
+		State: firstString(data, "state"),
+		Raw:   raw,
+	}
+	if r.VIN == "" {
+		r.VIN = vin
+	}
+	r.Retail, _ = toFloat(firstValue(data, "retail", "retail_value"))
+	r.TradeIn, _ = toFloat(firstValue(data, "tradeIn", "trade_in"))
+	r.RoughTradeIn, _ = toFloat(firstValue(data, "roughTradeIn", "rough_trade_in"))
+	r.AverageTradeIn, _ = toFloat(firstValue(data, "averageTradeIn", "average_trade_in"))
+	r.LoanValue, _ = toFloat(firstValue(data, "loanValue", "loan_value"))
+	r.MSRP, _ = toFloat(firstValue(data, "msrp"))
+	r.Mileage, _ = toInt(firstValue(data, "mileage", "uniform_mileage"))
+	return r
+}
+
+// MarketValuePoint is one valuation of a MarketValueSeries.
+type MarketValuePoint struct {
+	// Mileage is the odometer reading the vehicle was valued at.
+	Mileage int
+	Value   *MarketValueResult
+}
+
+// MarketValueSeries is a vehicle's retail value across mileages, with
+// summary statistics over the retail values of its points.
+type MarketValueSeries struct {
+	VIN    string
+	Points []MarketValuePoint
+	Min    float64
+	Max    float64
+	Avg    float64
+	// PercentChange is the change in retail value from the first point to
+	// the last, in percent, or 0 when the first value is 0.
+	PercentChange float64
+}
+
+// MarketValueHistory values vin at each of mileages, in the given order,
+// and summarizes how the retail value changes. The API only values vehicles
+// as of today, so the series projects depreciation by mileage rather than
+// replaying past valuations. params holds further MarketValue parameters
+// such as "state" or "condition"; its "mileage" is ignored. It fails on the
+// first failed valuation.
+func (c *Client) MarketValueHistory(ctx context.Context, vin string, mileages []int, params map[string]string, opts ...CallOption) (*MarketValueSeries, error) {
+	s := &MarketValueSeries{VIN: vin}
+	for _, miles := range mileages {
+		p := withParam(withParam(params, "vin", vin), "mileage", strconv.Itoa(miles))
+		v, err := c.MarketValueTyped(ctx, p, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("valuing %s at %d miles: %w", vin, miles, err)
+		}
+		s.Points = append(s.Points, MarketValuePoint{Mileage: miles, Value: v})
+	}
+	s.summarize()
+	return s, nil
+}
+
+// summarize computes the statistics of s from its points.
+func (s *MarketValueSeries) summarize() {
+	if len(s.Points) == 0 {
+		return
+	}
+	s.Min, s.Max = s.Points[0].Value.Retail, s.Points[0].Value.Retail
+	var sum float64
+	for _, p := range s.Points {
+		v := p.Value.Retail
+		s.Min, s.Max = min(s.Min, v), max(s.Max, v)
+		sum += v
+	}
+	s.Avg = sum / float64(len(s.Points))
+	if first := s.Points[0].Value.Retail; first != 0 {
+		last := s.Points[len(s.Points)-1].Value.Retail
+		s.PercentChange = (last - first) / first * 100
+	}
+}
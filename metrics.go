@@ -0,0 +1,36 @@
+package carsxe
+
+import (
+	"strings"
+	"time"
+)
+
+// Metrics receives observations about the client's API calls.
+// Implementations must be safe for concurrent use.
+type Metrics interface {
+	// ObserveRequest is called after every network request. status is the
+	// exact HTTP status code, including on success (200, 204, 206, ...), or
+	// 0 when no response was received.
+	ObserveRequest(endpoint string, status int, d time.Duration, err error)
+	// ObserveCache is called for every cache lookup when a Cache is
+	// configured.
+	ObserveCache(endpoint string, hit bool)
+}
+
+// WithMetrics reports request and cache observations to m. It may be given
+// several times; every Metrics receives every observation.
+func WithMetrics(m Metrics) Option {
+	return func(c *Client) { c.metrics = append(c.metrics, m) }
+}
+
+func (c *Client) observeRequest(endpoint string, status int, d time.Duration, err error) {
+	for _, m := range c.metrics {
+		m.ObserveRequest(strings.TrimLeft(endpoint, "/"), status, d, err)
+	}
+}
+
+func (c *Client) observeCache(endpoint string, hit bool) {
+	for _, m := range c.metrics {
+		m.ObserveCache(strings.TrimLeft(endpoint, "/"), hit)
+	}
+}
@@ -0,0 +1,60 @@
+package carsxe
+
+import (
+	"context"
+	"strings"
+)
+
+// OBDResult is the typed form of an ObdCodesDecoder response.
+type OBDResult struct {
+	Code      string
+	Diagnosis string
+	// Make is the make hint the code was decoded for, if any.
+	Make string
+	// VendorSpecific is true when the code lies in a manufacturer-controlled
+	// range and was decoded with a make hint. Otherwise the generic SAE
+	// meaning was used.
+	VendorSpecific bool
+	// Raw is the decoded response body.
+	Raw map[string]any
+}
+
+// ObdCodesDecoderTyped is like ObdCodesDecoder but honors ctx, returns
+// errors and decodes the response into an OBDResult. params takes "code"
+// and, optionally, a "make" hint that is forwarded to the API to improve the
+// decoding of manufacturer-specific codes such as P1xxx. Without a hint the
+// generic decoding is used.
+func (c *Client) ObdCodesDecoderTyped(ctx context.Context, params map[string]string, opts ...CallOption) (*OBDResult, error) {
+	raw, err := c.ObdCodesDecoderContext(ctx, params, opts...)
+	if err != nil {
+		return nil, err
+	}
+	r := &OBDResult{
+		Code:      toString(raw["code"]),
+		Diagnosis: toString(raw["diagnosis"]),
+		Make:      strings.TrimSpace(params["make"]),
+		Raw:       raw,
+	}
+	if r.Code == "" {
+		r.Code = strings.ToUpper(strings.TrimSpace(params["code"]))
+	}
+	r.VendorSpecific = r.Make != "" && IsManufacturerOBDCode(r.Code)
+	return r, nil
+}
+
+// IsManufacturerOBDCode reports whether code falls in a range SAE J2012
+// reserves for manufacturer-specific definitions: P1xxx, P30xx-P33xx, and
+// B1/B2, C1/C2 and U1/U2 codes.
+func IsManufacturerOBDCode(code string) bool {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) < 4 {
+		return false
+	}
+	switch code[0] {
+	case 'P':
+		return code[1] == '1' || (code[1] == '3' && code[2] >= '0' && code[2] <= '3')
+	case 'B', 'C', 'U':
+		return code[1] == '1' || code[1] == '2'
+	}
+	return false
+}
@@ -0,0 +1,192 @@
+package carsxe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrNoValidVIN is the failure reason of a BatchVinOCR image in which no
+// VIN passing ValidateVIN was read.
+var ErrNoValidVIN = errors.New("carsxe: no valid VIN read from image")
+
+// ImageSource is one image for BatchVinOCR. Exactly one of URL, Path and
+// Reader should be set; the helpers ImageURL, ImageFile and ImageReader
+// build them.
+type ImageSource struct {
+	// Name identifies the image in results and error messages. It defaults
+	// to URL, the base name of Path, or "image-N" for readers.
+	Name   string
+	URL    string
+	Path   string
+	Reader io.Reader
+}
+
+// ImageURL returns an ImageSource for an image the API fetches from u.
+func ImageURL(u string) ImageSource { return ImageSource{URL: u} }
+
+// ImageFile returns an ImageSource uploading the file at path.
+func ImageFile(path string) ImageSource { return ImageSource{Path: path} }
+
+// ImageReader returns an ImageSource uploading the data read from r.
+func ImageReader(name string, r io.Reader) ImageSource { return ImageSource{Name: name, Reader: r} }
+
+// BatchOCROptions configures BatchVinOCR.
+type BatchOCROptions struct {
+	// Concurrency bounds the number of images processed at once
+	// (default 4).
+	Concurrency int
+	// MinConfidence ignores readings with a lower confidence (0 to 1).
+	MinConfidence float64
+}
+
+// OCRImageResult is the outcome of one BatchVinOCR image.
+type OCRImageResult struct {
+	// Index is the position of the image in the input slice.
+	Index int
+	Name  string
+	// VIN is the most confident reading that passed ValidateVIN, or "".
+	VIN        string
+	Confidence float64
+	// DuplicateOf is the index of the first image the same VIN was read
+	// from, or -1.
+	DuplicateOf int
+	// OCR is the full OCR result, including rejected candidates; nil when
+	// the request failed.
+	OCR *VinOCRResult
+	// Err is the failure reason: the request error, or ErrNoValidVIN when
+	// nothing readable passed validation.
+	Err error
+}
+
+// BatchOCRResult is the outcome of BatchVinOCR.
+type BatchOCRResult struct {
+	// Images holds one result per input image, in input order.
+	Images []OCRImageResult
+	// VINs lists the distinct valid VINs found, in input order.
+	VINs []string
+}
+
+// Failed returns the images that yielded no VIN.
+func (r *BatchOCRResult) Failed() []OCRImageResult {
+	var out []OCRImageResult
+	for _, img := range r.Images {
+		if img.Err != nil {
+			out = append(out, img)
+		}
+	}
+	return out
+}
+
+// BatchVinOCR runs VIN OCR on images, a mix of URLs, files and readers,
+// with at most opts.Concurrency requests at once. For each image the most
+// confident reading, among the VIN and its candidates, that is 17
+// characters long with a valid check digit is kept; images showing a VIN
+// already found are marked with DuplicateOf. Per-image failures are
+// reported in OCRImageResult.Err. The returned error is non-nil only when
+// ctx is cancelled, in which case images not processed carry ctx's error.
+func (c *Client) BatchVinOCR(ctx context.Context, images []ImageSource, opts BatchOCROptions, callOpts ...CallOption) (*BatchOCRResult, error) {
+	ctx = ctxOrBackground(ctx)
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+	out := &BatchOCRResult{Images: make([]OCRImageResult, len(images))}
+	done := make([]bool, len(images))
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, concurrency)
+	)
+launch:
+	for i, img := range images {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break launch
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			out.Images[i] = c.ocrImage(ctx, i, img, opts.MinConfidence, callOpts)
+			done[i] = true
+		}()
+	}
+	wg.Wait()
+
+	err := ctx.Err()
+	first := map[string]int{}
+	for i := range out.Images {
+		img := &out.Images[i]
+		if !done[i] {
+			*img = OCRImageResult{Index: i, Name: imageName(i, images[i]), Err: err}
+		}
+		img.DuplicateOf = -1
+		if img.VIN == "" {
+			continue
+		}
+		if j, ok := first[img.VIN]; ok {
+			img.DuplicateOf = j
+			continue
+		}
+		first[img.VIN] = i
+		out.VINs = append(out.VINs, img.VIN)
+	}
+	return out, err
+}
+
+// ocrImage processes one BatchVinOCR image.
+func (c *Client) ocrImage(ctx context.Context, i int, img ImageSource, minConfidence float64, opts []CallOption) OCRImageResult {
+	res := OCRImageResult{Index: i, Name: imageName(i, img)}
+	var (
+		raw map[string]any
+		err error
+	)
+	switch {
+	case img.URL != "":
+		raw, err = c.VinOCRContext(ctx, img.URL, opts...)
+	case img.Path != "":
+		raw, err = c.VinOCRFromFile(ctx, img.Path, opts...)
+	case img.Reader != nil:
+		raw, err = c.VinOCRFromReader(ctx, img.Reader, res.Name, opts...)
+	default:
+		err = fmt.Errorf("carsxe: image %s has no URL, path or reader", res.Name)
+	}
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	ocr := newVinOCRResult(raw)
+	res.OCR = &ocr
+
+	readings := append([]VinOCRCandidate{{VIN: ocr.VIN, Confidence: ocr.Confidence}}, ocr.Candidates...)
+	sort.SliceStable(readings, func(a, b int) bool { return readings[a].Confidence > readings[b].Confidence })
+	for _, r := range readings {
+		vin := strings.ToUpper(strings.TrimSpace(r.VIN))
+		if len(vin) == 17 && r.Confidence >= minConfidence && ValidateVIN(vin) == nil {
+			res.VIN, res.Confidence = vin, r.Confidence
+			return res
+		}
+	}
+	res.Err = fmt.Errorf("%w: %s", ErrNoValidVIN, res.Name)
+	return res
+}
+
+// imageName returns the display name of the i-th image.
+func imageName(i int, img ImageSource) string {
+	switch {
+	case img.Name != "":
+		return img.Name
+	case img.URL != "":
+		return img.URL
+	case img.Path != "":
+		return filepath.Base(img.Path)
+	}
+	return "image-" + strconv.Itoa(i)
+}
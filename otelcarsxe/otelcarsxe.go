@@ -0,0 +1,119 @@
+// Package otelcarsxe instruments the CarsXE client with OpenTelemetry
+// tracing and metrics. It lives in its own package so that programs not
+// using OpenTelemetry do not depend on it.
+//
+//	client := carsxe.New(apiKey,
+//		otelcarsxe.WithTracerProvider(otel.GetTracerProvider()),
+//		otelcarsxe.WithMeterProvider(otel.GetMeterProvider()),
+//	)
+package otelcarsxe
+
+import (
+	"errors"
+	"time"
+
+	carsxe "github.com/carsxe/carsxe-go-package"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope used for tracers and meters.
+const ScopeName = "github.com/carsxe/carsxe-go-package/otelcarsxe"
+
+// Attribute keys set on spans and metrics.
+const (
+	AttrEndpoint   = attribute.Key("carsxe.endpoint")
+	AttrAttempts   = attribute.Key("carsxe.attempts")
+	AttrFromCache  = attribute.Key("carsxe.from_cache")
+	AttrStatusCode = attribute.Key("http.response.status_code")
+	AttrMethod     = attribute.Key("http.request.method")
+)
+
+// WithTracerProvider creates a client span from tp for every API call,
+// covering retries and cache lookups, with the endpoint, method, status
+// code, number of attempts and cache outcome as attributes. The span
+// context is propagated to the API in the request headers using the global
+// propagator (see otel.SetTextMapPropagator).
+func WithTracerProvider(tp trace.TracerProvider) carsxe.Option {
+	tracer := tp.Tracer(ScopeName)
+	return carsxe.WithInterceptor(func(next carsxe.CallHandler) carsxe.CallHandler {
+		return func(call *carsxe.Call) (*carsxe.Response, error) {
+			req := call.Request
+			ctx, span := tracer.Start(req.Context(), "carsxe "+call.Endpoint,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(AttrEndpoint.String(call.Endpoint), AttrMethod.String(req.Method)))
+			defer span.End()
+
+			req = req.WithContext(ctx)
+			otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+			call.Request = req
+
+			resp, err := next(call)
+			span.SetAttributes(resultAttrs(resp, err)...)
+			if resp != nil {
+				span.SetAttributes(AttrFromCache.Bool(resp.FromCache))
+			}
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			return resp, err
+		}
+	})
+}
+
+// WithMeterProvider records the duration of every API call, including
+// retries, in the carsxe.client.call.duration histogram (seconds) and counts
+// failed calls in carsxe.client.call.errors, both broken down by endpoint
+// and status code. Instrument creation errors are reported to the global
+// OpenTelemetry error handler and leave the client uninstrumented.
+func WithMeterProvider(mp metric.MeterProvider) carsxe.Option {
+	meter := mp.Meter(ScopeName)
+	duration, err1 := meter.Float64Histogram("carsxe.client.call.duration",
+		metric.WithDescription("Duration of CarsXE API calls, including retries."),
+		metric.WithUnit("s"))
+	errorCount, err2 := meter.Int64Counter("carsxe.client.call.errors",
+		metric.WithDescription("Number of failed CarsXE API calls."),
+		metric.WithUnit("{call}"))
+	if err := errors.Join(err1, err2); err != nil {
+		otel.Handle(err)
+		return func(*carsxe.Client) {}
+	}
+	return carsxe.WithInterceptor(func(next carsxe.CallHandler) carsxe.CallHandler {
+		return func(call *carsxe.Call) (*carsxe.Response, error) {
+			start := time.Now()
+			resp, err := next(call)
+			ctx := call.Request.Context()
+			attrs := metric.WithAttributes(append(resultAttrs(resp, err),
+				AttrEndpoint.String(call.Endpoint), AttrMethod.String(call.Request.Method))...)
+			duration.Record(ctx, time.Since(start).Seconds(), attrs)
+			if err != nil {
+				errorCount.Add(ctx, 1, attrs)
+			}
+			return resp, err
+		}
+	})
+}
+
+// resultAttrs describes the outcome of a call.
+func resultAttrs(resp *carsxe.Response, err error) []attribute.KeyValue {
+	status := carsxe.StatusCode(err)
+	attempts := 1
+	if resp != nil {
+		status = resp.StatusCode
+		attempts = max(resp.Attempts, 1)
+	}
+	var retryErr *carsxe.RetryError
+	if errors.As(err, &retryErr) {
+		attempts = retryErr.Attempts
+	}
+	attrs := []attribute.KeyValue{AttrAttempts.Int(attempts)}
+	if status != 0 {
+		attrs = append(attrs, AttrStatusCode.Int(status))
+	}
+	return attrs
+}
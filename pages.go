@@ -0,0 +1,146 @@
+package carsxe
+
+import (
+	"context"
+	"iter"
+	"strconv"
+)
+
+// defaultMaxPages caps GetAllPages when PageOptions.MaxPages is unset.
+const defaultMaxPages = 100
+
+// PageOptions configures GetAllPages.
+type PageOptions struct {
+	// MaxPages is the most pages fetched before giving up (default 100).
+	MaxPages int
+	// NextToken extracts the token of the next page from a response,
+	// returning false when there are no more pages. The default understands
+	// "next"/"next_page"/"nextPage" tokens and "page"/"total_pages" counters.
+	NextToken func(resp map[string]any) (string, bool)
+	// TokenParam is the query parameter the token is sent in (default "page").
+	TokenParam string
+	// DataKey is the array concatenated across pages (default "data").
+	DataKey string
+}
+
+// GetAllPages fetches endpoint page by page and concatenates the data arrays
+// of every page. It stops when NextToken reports no more pages or after
+// MaxPages pages; truncated is true in the latter case if more pages were
+// still available. On error the items collected so far are returned.
+func (c *Client) GetAllPages(ctx context.Context, endpoint string, params map[string]string, opts PageOptions, callOpts ...CallOption) (items []any, truncated bool, err error) {
+	opts = opts.withDefaults()
+	truncated, err = c.eachPage(ctx, endpoint, params, opts, callOpts, func(resp map[string]any) bool {
+		items = append(items, asSlice(resp[opts.DataKey])...)
+		return true
+	})
+	return items, truncated, err
+}
+
+// Pages returns an iterator over the decoded pages of endpoint, following
+// the next-page token as GetAllPages does until there are no more pages or
+// MaxPages pages were fetched. An error, including ctx's once it is
+// cancelled, is yielded as the last element.
+//
+//	for page, err := range client.Pages(ctx, "history", params, carsxe.PageOptions{}) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func (c *Client) Pages(ctx context.Context, endpoint string, params map[string]string, opts PageOptions, callOpts ...CallOption) iter.Seq2[map[string]any, error] {
+	opts = opts.withDefaults()
+	return func(yield func(map[string]any, error) bool) {
+		stopped := false
+		_, err := c.eachPage(ctx, endpoint, params, opts, callOpts, func(resp map[string]any) bool {
+			stopped = !yield(resp, nil)
+			return !stopped
+		})
+		if err != nil && !stopped {
+			yield(nil, err)
+		}
+	}
+}
+
+// Items is like Pages but yields the elements of each page's DataKey array
+// one by one.
+func (c *Client) Items(ctx context.Context, endpoint string, params map[string]string, opts PageOptions, callOpts ...CallOption) iter.Seq2[any, error] {
+	opts = opts.withDefaults()
+	return func(yield func(any, error) bool) {
+		for page, err := range c.Pages(ctx, endpoint, params, opts, callOpts...) {
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			for _, item := range asSlice(page[opts.DataKey]) {
+				if !yield(item, nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// withDefaults fills in the unset fields of o.
+func (o PageOptions) withDefaults() PageOptions {
+	if o.MaxPages <= 0 {
+		o.MaxPages = defaultMaxPages
+	}
+	if o.NextToken == nil {
+		o.NextToken = defaultNextToken
+	}
+	if o.TokenParam == "" {
+		o.TokenParam = "page"
+	}
+	if o.DataKey == "" {
+		o.DataKey = "data"
+	}
+	return o
+}
+
+// eachPage fetches the pages of endpoint and passes each to fn until there
+// are no more pages, fn returns false or MaxPages pages were fetched;
+// truncated reports the last case when more pages were available.
+func (c *Client) eachPage(ctx context.Context, endpoint string, params map[string]string, opts PageOptions, callOpts []CallOption, fn func(resp map[string]any) bool) (truncated bool, err error) {
+	ctx = ctxOrBackground(ctx)
+	seen := map[string]bool{}
+	for page := 0; ; page++ {
+		if page == opts.MaxPages {
+			return true, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return false, err
+		}
+		resp, err := c.GetContext(ctx, endpoint, params, callOpts...)
+		if err != nil {
+			return false, err
+		}
+		if !fn(resp) {
+			return false, nil
+		}
+
+		next, ok := opts.NextToken(resp)
+		if !ok || next == "" || seen[next] {
+			return false, nil
+		}
+		seen[next] = true
+		params = withParam(params, opts.TokenParam, next)
+	}
+}
+
+// defaultNextToken finds the next-page token in common pagination shapes.
+func defaultNextToken(resp map[string]any) (string, bool) {
+	for _, key := range []string{"next", "next_page", "nextPage", "next_cursor"} {
+		if tok := toString(resp[key]); tok != "" && tok != "false" {
+			return tok, true
+		}
+	}
+	page, okPage := toInt(resp["page"])
+	total, okTotal := toInt(resp["total_pages"])
+	if !okTotal {
+		total, okTotal = toInt(resp["totalPages"])
+	}
+	if okPage && okTotal && page < total {
+		return strconv.Itoa(page + 1), true
+	}
+	return "", false
+}
@@ -0,0 +1,229 @@
+package carsxe
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// ErrMissingParam is returned, with WithParamValidation, when a call lacks a
+// parameter its endpoint requires.
+type ErrMissingParam struct {
+	Endpoint string
+	Param    string
+}
+
+func (e *ErrMissingParam) Error() string {
+	if e.Endpoint == "" {
+		return fmt.Sprintf("carsxe: missing the required %q parameter", e.Param)
+	}
+	return fmt.Sprintf("carsxe: %s requires the %q parameter", e.Endpoint, e.Param)
+}
+
+// requiredParams lists the parameters each endpoint requires, as documented
+// in the API reference.
+var requiredParams = map[string][]string{
+	"specs":                        {"vin"},
+	"v2/marketvalue":               {"vin"},
+	"history":                      {"vin"},
+	"v1/recalls":                   {"vin"},
+	"v1/international-vin-decoder": {"vin"},
+	"v1/lien-theft":                {"vin"},
+	"v2/platedecoder":              {"plate", "country"},
+	"v1/ymm":                       {"year", "make", "model"},
+	"images":                       {"make", "model"},
+	"obdcodesdecoder":              {"code"},
+}
+
+// WithParamValidation checks GET calls to the known endpoints for their
+// required parameters and returns an *ErrMissingParam instead of sending a
+// request the API would reject. Unknown endpoints and extra parameters are
+// not checked.
+func WithParamValidation() Option {
+	return func(c *Client) { c.validateParams = true }
+}
+
+// checkParams enforces WithParamValidation.
+func (c *Client) checkParams(endpoint string, params url.Values) error {
+	if !c.validateParams {
+		return nil
+	}
+	endpoint = strings.Trim(endpoint, "/")
+	for _, p := range requiredParams[endpoint] {
+		if strings.TrimSpace(params.Get(p)) == "" {
+			return &ErrMissingParam{Endpoint: endpoint, Param: p}
+		}
+	}
+	return nil
+}
+
+// The ...Params types are typed alternatives to the map[string]string
+// params of the endpoint methods. Encode checks the required fields and
+// returns the map to pass to the method:
+//
+//	params, err := carsxe.SpecsParams{VIN: vin, DeepData: true}.Encode()
+//	if err != nil {
+//		return err
+//	}
+//	specs, err := client.SpecsTyped(ctx, params)
+
+// SpecsParams are the parameters of the Specs endpoint.
+type SpecsParams struct {
+	VIN                   string
+	DeepData              bool
+	DisableIntVINDecoding bool
+}
+
+// Encode validates p and returns it as Specs params.
+func (p SpecsParams) Encode() (map[string]string, error) {
+	return encodeParams("specs", map[string]string{
+		"vin":                   p.VIN,
+		"deepdata":              boolParam(p.DeepData),
+		"disableIntVINDecoding": boolParam(p.DisableIntVINDecoding),
+	})
+}
+
+// MarketValueParams are the parameters of the MarketValue endpoint.
+type MarketValueParams struct {
+	VIN   string
+	State string
+}
+
+// Encode validates p and returns it as MarketValue params.
+func (p MarketValueParams) Encode() (map[string]string, error) {
+	return encodeParams("v2/marketvalue", map[string]string{"vin": p.VIN, "state": p.State})
+}
+
+// VINParams are the parameters of the endpoints that only take a VIN:
+// History, Recalls, InternationalVINDecoder and LienAndTheft.
+type VINParams struct {
+	VIN string
+}
+
+// Encode validates p and returns it as params.
+func (p VINParams) Encode() (map[string]string, error) {
+	if strings.TrimSpace(p.VIN) == "" {
+		return nil, &ErrMissingParam{Param: "vin"}
+	}
+	return encodeParams("", map[string]string{"vin": p.VIN})
+}
+
+// PlateDecoderParams are the parameters of the PlateDecoder endpoint.
+type PlateDecoderParams = PlateQuery
+
+// Encode validates q, including its country and state unless
+// AllowUnknownRegion is set, and returns it as PlateDecoder params. Country
+// defaults to US.
+func (q PlateQuery) Encode() (map[string]string, error) {
+	if q.Country == "" {
+		q.Country = CountryUS
+	}
+	if !q.AllowUnknownRegion {
+		if err := q.Country.Validate(); err != nil {
+			return nil, err
+		}
+		if q.State != "" {
+			if err := q.State.ValidateFor(q.Country); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return encodeParams("v2/platedecoder", map[string]string{
+		"plate":    q.Plate,
+		"country":  string(q.Country),
+		"state":    string(q.State),
+		"district": q.District,
+	})
+}
+
+// YearMakeModelParams are the parameters of the YearMakeModel endpoint.
+type YearMakeModelParams struct {
+	Year  int
+	Make  string
+	Model string
+	Trim  string
+}
+
+// Encode validates p and returns it as YearMakeModel params.
+func (p YearMakeModelParams) Encode() (map[string]string, error) {
+	return encodeParams("v1/ymm", map[string]string{
+		"year":  intParam(p.Year),
+		"make":  p.Make,
+		"model": p.Model,
+		"trim":  p.Trim,
+	})
+}
+
+// ImagesParams are the parameters of the Images endpoint.
+type ImagesParams struct {
+	Make        string
+	Model       string
+	Year        int
+	Trim        string
+	Color       string
+	Transparent bool
+	Angle       string
+	PhotoType   string
+	Size        string
+	License     string
+}
+
+// Encode validates p and returns it as Images params.
+func (p ImagesParams) Encode() (map[string]string, error) {
+	return encodeParams("images", map[string]string{
+		"make":        p.Make,
+		"model":       p.Model,
+		"year":        intParam(p.Year),
+		"trim":        p.Trim,
+		"color":       p.Color,
+		"transparent": boolParam(p.Transparent),
+		"angle":       p.Angle,
+		"photoType":   p.PhotoType,
+		"size":        p.Size,
+		"license":     p.License,
+	})
+}
+
+// ObdCodesParams are the parameters of the ObdCodesDecoder endpoint.
+type ObdCodesParams struct {
+	Code string
+	// Make is an optional hint for manufacturer-specific codes.
+	Make string
+}
+
+// Encode validates p and returns it as ObdCodesDecoder params.
+func (p ObdCodesParams) Encode() (map[string]string, error) {
+	return encodeParams("obdcodesdecoder", map[string]string{"code": p.Code, "make": p.Make})
+}
+
+// encodeParams drops empty values from params and checks that the ones
+// endpoint requires are present.
+func encodeParams(endpoint string, params map[string]string) (map[string]string, error) {
+	out := make(map[string]string, len(params))
+	for k, v := range params {
+		if v = strings.TrimSpace(v); v != "" {
+			out[k] = v
+		}
+	}
+	for _, p := range requiredParams[endpoint] {
+		if out[p] == "" {
+			return nil, &ErrMissingParam{Endpoint: endpoint, Param: p}
+		}
+	}
+	return out, nil
+}
+
+func boolParam(b bool) string {
+	if !b {
+		return ""
+	}
+	return "true"
+}
+
+func intParam(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return strconv.Itoa(n)
+}
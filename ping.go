@@ -0,0 +1,101 @@
+package carsxe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ErrUnauthorized is returned by Ping when the API rejects the client's key.
+var ErrUnauthorized = errors.New("carsxe: unauthorized")
+
+// pingCode is the OBD code Ping looks up.
+const pingCode = "P0000"
+
+// Ping checks that the API is reachable and accepts the client's key. It
+// returns nil on success, an error wrapping ErrUnauthorized (and the
+// underlying *APIError) on a 401 or 403 response, and the underlying error
+// otherwise. It honors ctx's deadline.
+//
+// CarsXE has no dedicated health endpoint, so Ping decodes a fixed generic
+// OBD code: it is the lightest authenticated call available, with a small
+// static response and no vehicle lookup. Depending on the plan it may still
+// count as a request. Ping bypasses the cache, retries and
+// WithOfflineOBDFallback, and other 4xx responses still prove that the key
+// was accepted.
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := c.ping(ctx)
+	return err
+}
+
+// ping implements Ping, also returning the HTTP status code received, or 0
+// when no response was.
+func (c *Client) ping(ctx context.Context) (int, error) {
+	var resp Response
+	_, err := c.GetContext(ctx, "obdcodesdecoder", map[string]string{"code": pingCode}, WithResponseInto(&resp), func(co *callOptions) {
+		co.noCache = true
+		co.noRetry = true
+	})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return resp.StatusCode, err
+	}
+	switch {
+	case apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden:
+		return apiErr.StatusCode, fmt.Errorf("%w: %w", ErrUnauthorized, err)
+	case apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500:
+		return apiErr.StatusCode, err
+	}
+	return apiErr.StatusCode, nil
+}
+
+// StatusResult is the outcome of Status.
+type StatusResult struct {
+	// OK reports whether the API is reachable and accepts the client's key.
+	OK bool
+	// Latency is how long the check took. It is near zero when the circuit
+	// breaker rejected the check without contacting the API.
+	Latency time.Duration
+	// Message is "ok" or a description of the failure.
+	Message string
+	// StatusCode is the HTTP status of the check's response, or 0 when no
+	// response was received.
+	StatusCode int
+	// Circuit is the state of the client's circuit breaker after the check;
+	// CircuitClosed when none is configured.
+	Circuit CircuitState
+	// Err is the error returned by Ping, nil when OK.
+	Err error
+}
+
+// Status runs Ping and reports the outcome in a form suited to readiness
+// probes and status pages:
+//
+//	st := client.Status(ctx)
+//	if !st.OK {
+//		http.Error(w, st.Message, http.StatusServiceUnavailable)
+//		return
+//	}
+//
+// The check goes through the circuit breaker set up by WithCircuitBreaker
+// like any other call: while the breaker is open Status fails fast with
+// ErrCircuitOpen, and once it is half-open the check is one of its probes,
+// so a successful Status closes it.
+func (c *Client) Status(ctx context.Context) StatusResult {
+	start := time.Now()
+	code, err := c.ping(ctx)
+	st := StatusResult{
+		OK:         err == nil,
+		Latency:    time.Since(start),
+		Message:    "ok",
+		StatusCode: code,
+		Circuit:    c.CircuitState(),
+		Err:        err,
+	}
+	if err != nil {
+		st.Message = err.Error()
+	}
+	return st
+}
@@ -0,0 +1,95 @@
+package carsxe
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidPlate is returned when a plate cannot match its country's format.
+var ErrInvalidPlate = errors.New("carsxe: invalid plate")
+
+// PlateFormat describes how plates of a single country are normalized.
+type PlateFormat struct {
+	// Strip lists the separator characters removed from the plate.
+	Strip string
+	// Pattern, when set, must match the normalized (upper-cased) plate.
+	Pattern *regexp.Regexp
+}
+
+// defaultPlateFormat applies to countries without a registered format.
+var defaultPlateFormat = PlateFormat{
+	Strip:   " -.·",
+	Pattern: regexp.MustCompile(`^[\p{L}\p{N}]{1,12}$`),
+}
+
+var (
+	plateFormatsMu sync.RWMutex
+	plateFormats   = map[string]PlateFormat{
+		"US": {Strip: " -.·", Pattern: regexp.MustCompile(`^[A-Z0-9]{1,8}$`)},
+		"CA": {Strip: " -.", Pattern: regexp.MustCompile(`^[A-Z0-9]{2,8}$`)},
+		"MX": {Strip: " -.", Pattern: regexp.MustCompile(`^[A-Z0-9]{5,7}$`)},
+		"AU": {Strip: " -.·", Pattern: regexp.MustCompile(`^[A-Z0-9]{1,9}$`)},
+		"NZ": {Strip: " -.", Pattern: regexp.MustCompile(`^[A-Z0-9]{1,6}$`)},
+		"GB": {Strip: " -.", Pattern: regexp.MustCompile(`^[A-Z0-9]{2,7}$`)},
+		"IE": {Strip: " -.", Pattern: regexp.MustCompile(`^[0-9]{2,3}[A-Z]{1,2}[0-9]{1,6}$`)},
+		"FR": {Strip: " -.", Pattern: regexp.MustCompile(`^([A-Z]{2}[0-9]{3}[A-Z]{2}|[0-9]{1,4}[A-Z]{1,3}[0-9]{2})$`)},
+		// Older province-code plates (MI 123456, ROMA A12345) are still valid
+		// next to the current AA 000 AA format.
+		"IT": {Strip: " -.", Pattern: regexp.MustCompile(`^[A-Z0-9]{5,10}$`)},
+		"ES": {Strip: " -.", Pattern: regexp.MustCompile(`^([0-9]{4}[A-Z]{3}|[A-Z]{1,2}[0-9]{4}[A-Z]{0,2})$`)},
+		"DE": {Strip: " -.", Pattern: regexp.MustCompile(`^[A-ZÄÖÜ]{1,3}[A-Z]{1,2}[0-9]{1,4}[EH]?$`)},
+		"NL": {Strip: " -.", Pattern: regexp.MustCompile(`^[A-Z0-9]{6}$`)},
+		"BR": {Strip: " -.", Pattern: regexp.MustCompile(`^[A-Z]{3}[0-9][A-Z0-9][0-9]{2}$`)},
+		"IN": {Strip: " -.", Pattern: regexp.MustCompile(`^[A-Z]{2}[0-9]{1,2}[A-Z]{0,3}[0-9]{1,4}$`)},
+		"PK": {Strip: " -.", Pattern: regexp.MustCompile(`^[A-Z0-9]{2,10}$`)},
+	}
+	// plateCountryAliases maps common non-ISO spellings to their ISO code.
+	plateCountryAliases = map[string]string{"UK": "GB"}
+)
+
+// RegisterPlateFormat adds or replaces the format used to normalize plates
+// for country. It is safe to call concurrently with NormalizePlate.
+func RegisterPlateFormat(country string, f PlateFormat) {
+	plateFormatsMu.Lock()
+	defer plateFormatsMu.Unlock()
+	plateFormats[strings.ToUpper(strings.TrimSpace(country))] = f
+}
+
+// NormalizePlate strips separators from plate and upper-cases it according
+// to the rules registered for country (default "US"). An error wrapping
+// ErrInvalidPlate is returned when the result cannot match the country's
+// format.
+func NormalizePlate(plate, country string) (string, error) {
+	country = strings.ToUpper(strings.TrimSpace(country))
+	if country == "" {
+		country = "US"
+	}
+	if alias, ok := plateCountryAliases[country]; ok {
+		country = alias
+	}
+	plateFormatsMu.RLock()
+	f, ok := plateFormats[country]
+	plateFormatsMu.RUnlock()
+	if !ok {
+		f = defaultPlateFormat
+	}
+
+	out := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(f.Strip, r) {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(plate))
+	out = strings.ToUpper(out)
+
+	if out == "" {
+		return "", fmt.Errorf("%w: plate is empty", ErrInvalidPlate)
+	}
+	if f.Pattern != nil && !f.Pattern.MatchString(out) {
+		return "", fmt.Errorf("%w: %q does not match the %s plate format", ErrInvalidPlate, plate, country)
+	}
+	return out, nil
+}
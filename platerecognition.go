@@ -0,0 +1,144 @@
+package carsxe
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoConfidentMatch is returned by PlateImageRecognitionTyped when
+// WithMinConfidence or WithMaxCandidates is set and no plate passes them.
+var ErrNoConfidentMatch = errors.New("carsxe: no plate matched with enough confidence")
+
+// PlateRecognitionResult is the typed form of a PlateImageRecognition
+// response.
+type PlateRecognitionResult struct {
+	// Plates lists the plates found in the image, most confident first as
+	// returned by the API.
+	Plates []RecognizedPlate
+	// Raw is the decoded response body.
+	Raw map[string]any
+}
+
+// RecognizedPlate is one plate read from an image.
+type RecognizedPlate struct {
+	Plate      string
+	Confidence float64
+	// DetectionConfidence is how sure the API is that Box holds a plate,
+	// as opposed to Confidence, which scores the reading of its text.
+	DetectionConfidence float64
+	// Box locates the plate in the image.
+	Box BoundingBox
+	// Candidates lists alternative readings of the plate, most confident
+	// first.
+	Candidates []PlateCandidate
+	// Region is the API's region code, e.g. "us-ca".
+	Region      string
+	VehicleType string
+	Raw         map[string]any
+}
+
+// PlateCandidate is one possible reading of a recognized plate.
+type PlateCandidate struct {
+	Plate      string
+	Confidence float64
+}
+
+// BoundingBox is a rectangle in image pixel coordinates.
+type BoundingBox struct {
+	XMin, YMin, XMax, YMax int
+}
+
+// WithMinConfidence drops plates and candidates read with a confidence
+// below min (0 to 1) from the result of PlateImageRecognitionTyped.
+func WithMinConfidence(min float64) CallOption {
+	return func(co *callOptions) { co.minConfidence = min }
+}
+
+// WithMaxCandidates keeps at most n candidates per plate in the result of
+// PlateImageRecognitionTyped. Plates whose reading passes WithMinConfidence
+// are kept even when n is 0.
+func WithMaxCandidates(n int) CallOption {
+	return func(co *callOptions) {
+		co.maxCandidates = max(n, 0)
+		co.limitCandidates = true
+	}
+}
+
+// PlateImageRecognitionTyped is like PlateImageRecognition but honors ctx,
+// returns errors and decodes the response into a PlateRecognitionResult.
+// With WithMinConfidence or WithMaxCandidates the plates are filtered
+// locally, and ErrNoConfidentMatch is returned if none is left.
+func (c *Client) PlateImageRecognitionTyped(ctx context.Context, imageURL string, opts ...CallOption) (*PlateRecognitionResult, error) {
+	raw, err := c.PlateImageRecognitionContext(ctx, imageURL, opts...)
+	if err != nil {
+		return nil, err
+	}
+	r := &PlateRecognitionResult{Raw: raw}
+	for _, item := range asSlice(raw["results"]) {
+		if m := asMap(item); m != nil {
+			r.Plates = append(r.Plates, newRecognizedPlate(m))
+		}
+	}
+	co := newCallOptions(opts)
+	if co.minConfidence <= 0 && !co.limitCandidates {
+		return r, nil
+	}
+	r.Plates = filterPlates(r.Plates, co.minConfidence, co.maxCandidates, co.limitCandidates)
+	if len(r.Plates) == 0 {
+		return nil, ErrNoConfidentMatch
+	}
+	return r, nil
+}
+
+// newRecognizedPlate maps one entry of the "results" array.
+func newRecognizedPlate(m map[string]any) RecognizedPlate {
+	p := RecognizedPlate{Plate: firstString(m, "plate"), Raw: m}
+	p.Confidence, _ = toFloat(firstValue(m, "confidence", "score"))
+	p.DetectionConfidence, _ = toFloat(firstValue(m, "dscore", "detection_score"))
+	if box := asMap(firstValue(m, "box", "bounding_box")); box != nil {
+		p.Box.XMin, _ = toInt(box["xmin"])
+		p.Box.YMin, _ = toInt(box["ymin"])
+		p.Box.XMax, _ = toInt(box["xmax"])
+		p.Box.YMax, _ = toInt(box["ymax"])
+	}
+	for _, item := range asSlice(m["candidates"]) {
+		cm := asMap(item)
+		if cm == nil {
+			continue
+		}
+		cand := PlateCandidate{Plate: firstString(cm, "plate")}
+		cand.Confidence, _ = toFloat(firstValue(cm, "confidence", "score"))
+		p.Candidates = append(p.Candidates, cand)
+	}
+	if region := asMap(m["region"]); region != nil {
+		p.Region = firstString(region, "code")
+	} else {
+		p.Region = firstString(m, "region")
+	}
+	if vehicle := asMap(m["vehicle"]); vehicle != nil {
+		p.VehicleType = firstString(vehicle, "type")
+	}
+	return p
+}
+
+// filterPlates applies WithMinConfidence and WithMaxCandidates to plates.
+func filterPlates(plates []RecognizedPlate, minConfidence float64, maxCandidates int, limit bool) []RecognizedPlate {
+	var out []RecognizedPlate
+	for _, p := range plates {
+		if p.Confidence < minConfidence {
+			continue
+		}
+		var cands []PlateCandidate
+		for _, cand := range p.Candidates {
+			if cand.Confidence >= minConfidence {
+				cands = append(cands, cand)
+			}
+		}
+		if limit && len(cands) > maxCandidates {
+			cands = cands[:maxCandidates]
+		}
+		p.Candidates = cands
+		out = append(out, p)
+	}
+	return out
+}
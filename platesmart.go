@@ -0,0 +1,90 @@
+package carsxe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrPlateNotMatched is returned by PlateDecoderSmart when the plate was not
+// found in any of the states tried.
+var ErrPlateNotMatched = errors.New("carsxe: plate not matched")
+
+// defaultPlateStates lists the states PlateDecoderSmart tries when none are
+// given, most registered vehicles first.
+var defaultPlateStates = map[Country][]State{
+	CountryUS: {"CA", "TX", "FL", "NY", "PA", "IL", "OH", "GA", "NC", "MI", "NJ", "VA", "WA", "AZ", "MA"},
+	CountryCA: {"ON", "QC", "BC", "AB", "MB", "SK", "NS", "NB"},
+	CountryAU: {"NSW", "VIC", "QLD", "WA", "SA", "TAS", "ACT", "NT"},
+}
+
+// PlateAttempt records one state tried by PlateDecoderSmart.
+type PlateAttempt struct {
+	State State
+	// Err is why the state did not match: a not-found error, an invalid
+	// state, or ErrPlateNotMatched when the response held no vehicle.
+	Err error
+}
+
+// SmartPlateResult is the outcome of PlateDecoderSmart.
+type SmartPlateResult struct {
+	// Result is the first match; nil if none was found.
+	Result *PlateResult
+	// Attempts lists the states tried, in order, including the match.
+	Attempts []PlateAttempt
+}
+
+// PlateDecoderSmart looks up plate in each candidate state in turn, for
+// when the state of registration is only guessed, and stops at the first
+// response identifying a vehicle (with a VIN or a make). Without candidate
+// states a prioritized list of the most populous states of country is used
+// (US, CA and AU); for other countries the plate is looked up once without
+// a state. Each lookup goes through the client's rate limiter like any
+// other call.
+//
+// States that are not found or not valid for country are skipped and
+// recorded in Attempts. Any other error, such as a rate limit or a
+// cancelled ctx, stops the search and is returned with the attempts so far.
+// ErrPlateNotMatched is returned when every state was tried without a
+// match.
+func (c *Client) PlateDecoderSmart(ctx context.Context, plate, country string, candidateStates []string, opts ...CallOption) (*SmartPlateResult, error) {
+	co := Country(strings.ToUpper(strings.TrimSpace(country)))
+	if co == "" {
+		co = CountryUS
+	}
+	states := make([]State, 0, len(candidateStates))
+	seen := map[State]bool{}
+	for _, s := range candidateStates {
+		st := State(strings.ToUpper(strings.TrimSpace(s)))
+		if st != "" && !seen[st] {
+			seen[st] = true
+			states = append(states, st)
+		}
+	}
+	if len(states) == 0 {
+		states = defaultPlateStates[co]
+	}
+	if len(states) == 0 {
+		states = []State{""}
+	}
+
+	out := &SmartPlateResult{}
+	for _, st := range states {
+		res, err := c.PlateDecoderTyped(ctx, PlateQuery{Plate: plate, Country: co, State: st}, opts...)
+		if err == nil && res.VIN == "" && res.Make == "" {
+			err = ErrPlateNotMatched
+		}
+		out.Attempts = append(out.Attempts, PlateAttempt{State: st, Err: err})
+		switch {
+		case err == nil:
+			out.Result = res
+			return out, nil
+		case IsNotFound(err), errors.Is(err, ErrUnknownRegion), errors.Is(err, ErrPlateNotMatched):
+			continue
+		default:
+			return out, err
+		}
+	}
+	return out, fmt.Errorf("%w: %s tried in %d state(s)", ErrPlateNotMatched, plate, len(out.Attempts))
+}
@@ -0,0 +1,229 @@
+package carsxe
+
+import (
+	"container/list"
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Priority orders requests that are waiting for the client's rate limiter or
+// concurrency limit.
+//
+// Scheduling is strictly by priority: whenever a slot or token frees up it is
+// handed to the longest-waiting request of the highest waiting priority, so
+// requests of equal priority are served first-in first-out. Lower priorities
+// are only served when no higher-priority request is waiting, which means a
+// sustained stream of high-priority calls can starve low-priority ones. A
+// request already waiting for a rate-limit token is not preempted, so a newly
+// arrived high-priority request may wait behind at most one such request.
+type Priority int
+
+// Supported priorities. The zero value is PriorityNormal.
+const (
+	PriorityLow    Priority = -1
+	PriorityNormal Priority = 0
+	PriorityHigh   Priority = 1
+)
+
+// CallOption configures a single API call.
+type CallOption func(*callOptions)
+
+// callOptions holds the per-call settings built from CallOptions.
+type callOptions struct {
+	priority     Priority
+	response     *Response
+	idempotent   bool
+	timeout      time.Duration
+	noCache      bool
+	refreshCache bool
+	noRetry      bool
+	header       http.Header
+	query        map[string]string
+
+	// Used by PlateImageRecognitionTyped only.
+	minConfidence   float64
+	maxCandidates   int
+	limitCandidates bool
+}
+
+func newCallOptions(opts []CallOption) *callOptions {
+	co := &callOptions{}
+	for _, o := range opts {
+		o(co)
+	}
+	return co
+}
+
+// WithPriority sets the scheduling priority of a call (default
+// PriorityNormal). It only has an effect when the client is configured with
+// WithRateLimit or WithMaxConcurrency.
+func WithPriority(p Priority) CallOption {
+	return func(co *callOptions) {
+		if p < PriorityLow {
+			p = PriorityLow
+		}
+		if p > PriorityHigh {
+			p = PriorityHigh
+		}
+		co.priority = p
+	}
+}
+
+// WithCallTimeout bounds a single call, including any waits for rate limits
+// and retries, to d. The client's overall HTTP timeout (see WithTimeout)
+// still applies to each attempt as a ceiling.
+func WithCallTimeout(d time.Duration) CallOption {
+	return func(co *callOptions) { co.timeout = d }
+}
+
+// WithRateLimit limits outgoing requests to rps per second with bursts of up
+// to burst requests. Waiting requests are served in Priority order.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(c *Client) {
+		if burst < 1 {
+			burst = 1
+		}
+		c.limiter = rate.NewLimiter(rate.Limit(rps), burst)
+		c.limiterGate = newPrioritySemaphore(1)
+	}
+}
+
+// WithRateLimiter throttles outgoing requests with l, which may be shared
+// with other clients or code to enforce a single account-wide budget.
+// Waiting requests are served in Priority order.
+func WithRateLimiter(l *rate.Limiter) Option {
+	return func(c *Client) {
+		c.limiter = l
+		c.limiterGate = newPrioritySemaphore(1)
+	}
+}
+
+// WithMaxConcurrency limits the number of requests in flight at once to n.
+// Waiting requests are served in Priority order.
+func WithMaxConcurrency(n int) Option {
+	return func(c *Client) {
+		if n > 0 {
+			c.concurrency = newPrioritySemaphore(n)
+		}
+	}
+}
+
+// acquireSlot waits for a concurrency slot and a rate-limit token. The
+// returned func releases the slot and must be called once the request is
+// done.
+func (c *Client) acquireSlot(ctx context.Context, p Priority) (func(), error) {
+	release := func() {}
+	if c.concurrency != nil {
+		if err := c.concurrency.acquire(ctx, p); err != nil {
+			return nil, err
+		}
+		release = c.concurrency.release
+	}
+	if c.limiter != nil {
+		if err := c.waitToken(ctx, p); err != nil {
+			release()
+			return nil, err
+		}
+	}
+	return release, nil
+}
+
+// waitToken waits for a rate-limit token. Only one request waits on the
+// limiter at a time, so tokens go to waiters in priority order.
+func (c *Client) waitToken(ctx context.Context, p Priority) error {
+	if err := c.limiterGate.acquire(ctx, p); err != nil {
+		return err
+	}
+	defer c.limiterGate.release()
+	return c.limiter.Wait(ctx)
+}
+
+// prioritySemaphore is a counting semaphore whose waiters are woken by
+// priority, then in arrival order.
+type prioritySemaphore struct {
+	mu     sync.Mutex
+	size   int
+	used   int
+	queues [3]list.List // indexed by Priority - PriorityLow
+}
+
+func newPrioritySemaphore(size int) *prioritySemaphore {
+	return &prioritySemaphore{size: size}
+}
+
+func (s *prioritySemaphore) acquire(ctx context.Context, p Priority) error {
+	s.mu.Lock()
+	if s.used < s.size && s.waitingLocked() == 0 {
+		s.used++
+		s.mu.Unlock()
+		return nil
+	}
+	ready := make(chan struct{})
+	q := &s.queues[p-PriorityLow]
+	elem := q.PushBack(ready)
+	s.mu.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		s.mu.Lock()
+		select {
+		case <-ready:
+			// Acquired just as ctx was cancelled; pass the slot on.
+			s.used--
+			s.notifyLocked()
+		default:
+			q.Remove(elem)
+		}
+		s.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+func (s *prioritySemaphore) release() {
+	s.mu.Lock()
+	s.used--
+	s.notifyLocked()
+	s.mu.Unlock()
+}
+
+func (s *prioritySemaphore) waitingLocked() int {
+	n := 0
+	for i := range s.queues {
+		n += s.queues[i].Len()
+	}
+	return n
+}
+
+// notifyLocked hands free slots to the highest-priority waiters.
+func (s *prioritySemaphore) notifyLocked() {
+	for s.used < s.size {
+		var front *list.Element
+		var q *list.List
+		for i := len(s.queues) - 1; i >= 0; i-- {
+			if front = s.queues[i].Front(); front != nil {
+				q = &s.queues[i]
+				break
+			}
+		}
+		if front == nil {
+			return
+		}
+		s.used++
+		q.Remove(front)
+		close(front.Value.(chan struct{}))
+	}
+}
+
+// WithSerialized sends requests one at a time in strict arrival order, which
+// is the simplest safe mode for keys that allow no concurrency. Priorities
+// are ignored for ordering in this mode. A request whose context is
+// cancelled while queued is dropped from the queue without being sent.
+func WithSerialized() Option {
+	return func(c *Client) { c.serial = newPrioritySemaphore(1) }
+}
@@ -0,0 +1,138 @@
+package carsxe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// ProfileSection names a call made by VehicleProfile.
+type ProfileSection string
+
+// Sections of a VehicleProfile.
+const (
+	ProfileSpecs       ProfileSection = "specs"
+	ProfileHistory     ProfileSection = "history"
+	ProfileMarketValue ProfileSection = "marketvalue"
+	ProfileRecalls     ProfileSection = "recalls"
+)
+
+// allProfileSections is the default set fetched by VehicleProfile.
+var allProfileSections = []ProfileSection{ProfileSpecs, ProfileHistory, ProfileMarketValue, ProfileRecalls}
+
+// ProfileOptions selects what VehicleProfile fetches.
+type ProfileOptions struct {
+	// Sections lists the calls to make; nil means all of them.
+	Sections []ProfileSection
+	// MarketValueParams adds parameters to the MarketValue call, e.g.
+	// "mileage" or "state".
+	MarketValueParams map[string]string
+	// CallOptions are applied to every call.
+	CallOptions []CallOption
+}
+
+// VehicleProfile merges the typed results of several calls for one VIN.
+// Sections that were not requested or failed are nil.
+type VehicleProfile struct {
+	VIN         string
+	Specs       *SpecsResult
+	History     *HistoryResult
+	MarketValue *MarketValueResult
+	Recalls     *RecallsResult
+	// Errors holds the error of each section that failed.
+	Errors map[ProfileSection]error
+}
+
+// Err joins the errors of the failed sections, sorted by section name, or
+// returns nil.
+func (p *VehicleProfile) Err() error {
+	var errs []error
+	for _, s := range slices.Sorted(maps.Keys(p.Errors)) {
+		errs = append(errs, p.Errors[s])
+	}
+	return errors.Join(errs...)
+}
+
+// VehicleProfile fetches the selected sections for vin concurrently and
+// merges them into a VehicleProfile. Each call goes through the client's
+// rate limiter, concurrency limit and retries like any other. A failed
+// section is recorded in VehicleProfile.Errors and does not affect the
+// others; the returned error is non-nil only when every section failed or
+// vin is invalid.
+func (c *Client) VehicleProfile(ctx context.Context, vin string, opts ProfileOptions) (*VehicleProfile, error) {
+	ctx = ctxOrBackground(ctx)
+	if c.validateVINs {
+		if err := ValidateVIN(vin); err != nil {
+			return nil, err
+		}
+	}
+	sections := opts.Sections
+	if sections == nil {
+		sections = allProfileSections
+	}
+	p := &VehicleProfile{VIN: vin, Errors: map[ProfileSection]error{}}
+	params := map[string]string{"vin": vin}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	fetch := func(s ProfileSection, fn func() error) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := fn(); err != nil {
+				mu.Lock()
+				p.Errors[s] = err
+				mu.Unlock()
+			}
+		}()
+	}
+	seen := map[ProfileSection]bool{}
+	for _, s := range sections {
+		if seen[s] {
+			continue
+		}
+		seen[s] = true
+		switch s {
+		case ProfileSpecs:
+			fetch(s, func() (err error) {
+				p.Specs, err = c.SpecsTyped(ctx, params, opts.CallOptions...)
+				return err
+			})
+		case ProfileHistory:
+			fetch(s, func() (err error) {
+				p.History, err = c.HistoryTyped(ctx, params, opts.CallOptions...)
+				return err
+			})
+		case ProfileMarketValue:
+			mvParams := maps.Clone(opts.MarketValueParams)
+			if mvParams == nil {
+				mvParams = map[string]string{}
+			}
+			mvParams["vin"] = vin
+			fetch(s, func() (err error) {
+				p.MarketValue, err = c.MarketValueTyped(ctx, mvParams, opts.CallOptions...)
+				return err
+			})
+		case ProfileRecalls:
+			fetch(s, func() (err error) {
+				p.Recalls, err = c.RecallsTyped(ctx, params, opts.CallOptions...)
+				return err
+			})
+		default:
+			mu.Lock()
+			p.Errors[s] = fmt.Errorf("carsxe: unknown profile section %q", s)
+			mu.Unlock()
+		}
+	}
+	wg.Wait()
+
+	if len(p.Errors) == len(seen) {
+		return p, p.Err()
+	}
+	return p, nil
+}
@@ -0,0 +1,40 @@
+package carsxe
+
+import "net/http"
+
+// WithQueryParam sets query parameter key to value on a single call,
+// replacing the value from the params map. An empty value removes the
+// parameter. The API key cannot be overridden.
+func WithQueryParam(key, value string) CallOption {
+	return func(co *callOptions) {
+		if co.query == nil {
+			co.query = map[string]string{}
+		}
+		co.query[key] = value
+	}
+}
+
+// WithoutSource leaves the "source" query parameter (see WithSource) off a
+// single call.
+func WithoutSource() CallOption {
+	return WithQueryParam("source", "")
+}
+
+// applyQuery applies the per-call query overrides to req.
+func applyQuery(req *http.Request, co *callOptions) {
+	if len(co.query) == 0 {
+		return
+	}
+	q := req.URL.Query()
+	for k, v := range co.query {
+		if k == "key" {
+			continue
+		}
+		if v == "" {
+			q.Del(k)
+		} else {
+			q.Set(k, v)
+		}
+	}
+	req.URL.RawQuery = q.Encode()
+}
@@ -0,0 +1,142 @@
+package carsxe
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RecallsResult is the typed form of a Recalls response.
+type RecallsResult struct {
+	VIN          string
+	Make         string
+	Model        string
+	ModelYear    string
+	Manufacturer string
+	HasRecalls   bool
+	Campaigns    []RecallCampaign
+	// Raw is the decoded response body.
+	Raw map[string]any
+}
+
+// RecallCampaign is a single recall affecting the vehicle. Fields the API
+// did not return are left at their zero value; Raw keeps every value as
+// received.
+type RecallCampaign struct {
+	NHTSAID         string
+	ManufacturerID  string
+	Name            string
+	Component       string
+	Description     string
+	Risk            string
+	Remedy          string
+	Status          string
+	RecallDate      time.Time
+	RemedyAvailable bool
+	// AffectedUnits is the number of vehicles the campaign covers.
+	AffectedUnits int
+	// ManufacturedFrom and ManufacturedTo bound the production window of
+	// the affected vehicles.
+	ManufacturedFrom time.Time
+	ManufacturedTo   time.Time
+	Raw              map[string]any
+}
+
+// RecallsTyped is like Recalls but honors ctx, returns errors and decodes the
+// response into a RecallsResult.
+func (c *Client) RecallsTyped(ctx context.Context, params map[string]string, opts ...CallOption) (*RecallsResult, error) {
+	raw, err := c.RecallsContext(ctx, params, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return newRecallsResult(raw), nil
+}
+
+// newRecallsResult maps a Recalls response onto RecallsResult.
+func newRecallsResult(raw map[string]any) *RecallsResult {
+	data := asMap(raw["data"])
+	if data == nil {
+		data = raw
+	}
+	r := &RecallsResult{
+		VIN:          toString(data["vin"]),
+		Make:         toString(data["make"]),
+		Model:        toString(data["model"]),
+		ModelYear:    toString(data["model_year"]),
+		Manufacturer: toString(data["manufacturer"]),
+		Raw:          raw,
+	}
+	for _, item := range asSlice(data["recalls"]) {
+		m := asMap(item)
+		if m == nil {
+			continue
+		}
+		r.Campaigns = append(r.Campaigns, newRecallCampaign(m))
+	}
+	r.HasRecalls = toBool(data["has_recalls"]) || len(r.Campaigns) > 0
+	return r
+}
+
+func newRecallCampaign(m map[string]any) RecallCampaign {
+	rc := RecallCampaign{
+		NHTSAID:         toString(firstValue(m, "nhtsa_id", "nhtsa_campaign_number", "campaign_number")),
+		ManufacturerID:  toString(m["manufacturer_id"]),
+		Name:            toString(m["recall_name"]),
+		Component:       toString(m["component"]),
+		Description:     toString(m["recall_description"]),
+		Risk:            toString(m["risk_description"]),
+		Remedy:          toString(m["recall_remedy"]),
+		Status:          toString(m["recall_status"]),
+		RemedyAvailable: toBool(m["remedy_available"]),
+		Raw:             m,
+	}
+	rc.RecallDate, _ = toTime(m["recall_date"])
+	rc.AffectedUnits, _ = toInt(firstValue(m, "affected_units", "potential_units_affected", "units_affected", "population"))
+	rc.ManufacturedFrom, _ = toTime(firstValue(m, "manufactured_from", "production_start", "manufacture_start_date", "begin_manufacture_date"))
+	rc.ManufacturedTo, _ = toTime(firstValue(m, "manufactured_to", "production_end", "manufacture_end_date", "end_manufacture_date"))
+	return rc
+}
+
+// IsOpen reports whether the recall has not been remedied on the vehicle.
+// A campaign without a status counts as open, so that nothing is missed.
+func (rc RecallCampaign) IsOpen() bool {
+	s := strings.ToLower(rc.Status)
+	if s == "" || strings.Contains(s, "incomplete") || strings.Contains(s, "open") {
+		return true
+	}
+	return !strings.Contains(s, "closed") && !strings.Contains(s, "complete") && !strings.Contains(s, "remedied")
+}
+
+// NHTSAURL returns the NHTSA page of the recall campaign, or "" when the
+// campaign has no NHTSA ID.
+func (rc RecallCampaign) NHTSAURL() string {
+	if rc.NHTSAID == "" {
+		return ""
+	}
+	return "https://www.nhtsa.gov/recalls?nhtsaId=" + url.QueryEscape(rc.NHTSAID)
+}
+
+// OpenRecalls returns the campaigns that are still open (see IsOpen).
+func (r *RecallsResult) OpenRecalls() []RecallCampaign {
+	var out []RecallCampaign
+	for _, rc := range r.Campaigns {
+		if rc.IsOpen() {
+			out = append(out, rc)
+		}
+	}
+	return out
+}
+
+// ByComponent returns the campaigns whose component contains component,
+// ignoring case, e.g. "air bags" or "electrical".
+func (r *RecallsResult) ByComponent(component string) []RecallCampaign {
+	component = strings.ToLower(strings.TrimSpace(component))
+	var out []RecallCampaign
+	for _, rc := range r.Campaigns {
+		if strings.Contains(strings.ToLower(rc.Component), component) {
+			out = append(out, rc)
+		}
+	}
+	return out
+}
@@ -0,0 +1,122 @@
+package carsxe
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// Redactor rewrites a sensitive value before it reaches logs or other
+// observability hooks.
+type Redactor func(value string) string
+
+// HashRedactor replaces a value with the first 8 hex characters of its
+// SHA-256 hash, which keeps values correlatable without storing them.
+func HashRedactor(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return "sha256:" + hex.EncodeToString(sum[:4])
+}
+
+// WithVINRedaction passes every "vin" parameter through r before it is
+// exposed by the client's observability features (logging and any other
+// hook that reports request parameters). Requests sent to the API are not
+// affected.
+func WithVINRedaction(r Redactor) Option {
+	return func(c *Client) { c.vinRedactor = r }
+}
+
+// redactQuery returns a copy of q that is safe to expose: the API key is
+// removed and VINs are redacted when WithVINRedaction is set.
+func (c *Client) redactQuery(q url.Values) url.Values {
+	out := make(url.Values, len(q))
+	for k, vs := range q {
+		if k == "key" {
+			continue
+		}
+		if c.vinRedactor != nil && strings.EqualFold(k, "vin") {
+			redacted := make([]string, len(vs))
+			for i, v := range vs {
+				redacted[i] = c.vinRedactor(v)
+			}
+			vs = redacted
+		}
+		out[k] = vs
+	}
+	return out
+}
+
+// redactedURL renders u with its query redacted by redactQuery.
+func (c *Client) redactedURL(u *url.URL) string {
+	cp := *u
+	cp.RawQuery = c.redactQuery(u.Query()).Encode()
+	return cp.String()
+}
+
+// keyParamRe matches the value of a "key" query parameter in a URL string.
+var keyParamRe = regexp.MustCompile(`([?&;]key=)[^&#\s"']*`)
+
+// SanitizeURL replaces the value of the "key" query parameter in u with
+// "***". It works on any string containing URLs, such as an error message.
+func SanitizeURL(u string) string {
+	return keyParamRe.ReplaceAllString(u, "${1}***")
+}
+
+// sanitize is SanitizeURL that also masks the client's API key wherever it
+// appears, e.g. when it was passed as another parameter by mistake.
+func (c *Client) sanitize(s string) string {
+	s = SanitizeURL(s)
+	if c.apiKey != "" {
+		s = strings.ReplaceAll(s, c.apiKey, "***")
+	}
+	if c.keys != nil {
+		for _, k := range c.keys.keys {
+			s = strings.ReplaceAll(s, k.key, "***")
+		}
+	}
+	return s
+}
+
+// redactText is sanitize that also, under WithVINRedaction, replaces the
+// VINs sent with req, for free text such as bodies and error messages.
+func (c *Client) redactText(req *http.Request, s string) string {
+	s = c.sanitize(s)
+	if c.vinRedactor == nil || req == nil {
+		return s
+	}
+	for k, vs := range req.URL.Query() {
+		if !strings.EqualFold(k, "vin") {
+			continue
+		}
+		for _, v := range vs {
+			if v == "" {
+				continue
+			}
+			redacted := c.vinRedactor(v)
+			for _, form := range []string{v, strings.ToUpper(v), strings.ToLower(v)} {
+				s = strings.ReplaceAll(s, form, redacted)
+			}
+		}
+	}
+	return s
+}
+
+// sanitizeError masks the API key in the request URL that net/http embeds
+// in transport errors and in the text of API error responses.
+func (c *Client) sanitizeError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		cp := *urlErr
+		cp.URL = c.sanitize(urlErr.URL)
+		return &cp
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		apiErr.Body = c.sanitize(apiErr.Body)
+		apiErr.Message = c.sanitize(apiErr.Message)
+	}
+	return err
+}
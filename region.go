@@ -0,0 +1,279 @@
+package carsxe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownRegion is returned when a Country or State is not a known ISO
+// 3166 code.
+var ErrUnknownRegion = errors.New("carsxe: unknown region")
+
+// Country is an ISO 3166-1 alpha-2 country code.
+type Country string
+
+// Countries supported by the plate decoder.
+const (
+	CountryUS Country = "US"
+	CountryCA Country = "CA"
+	CountryMX Country = "MX"
+	CountryAU Country = "AU"
+	CountryNZ Country = "NZ"
+	CountryGB Country = "GB"
+	CountryIE Country = "IE"
+	CountryFR Country = "FR"
+	CountryIT Country = "IT"
+	CountryES Country = "ES"
+	CountryDE Country = "DE"
+	CountryNL Country = "NL"
+	CountryBR Country = "BR"
+	CountryIN Country = "IN"
+	CountryPK Country = "PK"
+)
+
+var knownCountries = map[Country]bool{
+	CountryUS: true, CountryCA: true, CountryMX: true, CountryAU: true,
+	CountryNZ: true, CountryGB: true, CountryIE: true, CountryFR: true,
+	CountryIT: true, CountryES: true, CountryDE: true, CountryNL: true,
+	CountryBR: true, CountryIN: true, CountryPK: true,
+}
+
+// countryHints maps common mistakes to the intended code.
+var countryHints = map[string]Country{
+	"USA": CountryUS, "UK": CountryGB, "CAN": CountryCA, "MEX": CountryMX,
+	"AUS": CountryAU, "NZL": CountryNZ, "GBR": CountryGB, "IRL": CountryIE,
+	"FRA": CountryFR, "ITA": CountryIT, "ESP": CountryES, "DEU": CountryDE,
+	"GER": CountryDE, "NLD": CountryNL, "BRA": CountryBR, "IND": CountryIN,
+	"PAK": CountryPK,
+}
+
+// Validate returns an error wrapping ErrUnknownRegion if c is not a known
+// country code, suggesting the intended code for common typos such as "USA".
+func (c Country) Validate() error {
+	if knownCountries[c] {
+		return nil
+	}
+	if hint, ok := countryHints[strings.ToUpper(strings.TrimSpace(string(c)))]; ok {
+		return fmt.Errorf("%w: country %q (did you mean %q?)", ErrUnknownRegion, string(c), string(hint))
+	}
+	return fmt.Errorf("%w: country %q is not a supported ISO 3166-1 alpha-2 code", ErrUnknownRegion, string(c))
+}
+
+// State is the subdivision part of an ISO 3166-2 code, e.g. "CA" for
+// California or "NSW" for New South Wales. Its meaning depends on the
+// country it is used with.
+type State string
+
+// knownStates lists the subdivisions validated for each country. Countries
+// without an entry accept any state.
+var knownStates = map[Country][]State{
+	CountryUS: {
+		"AL", "AK", "AZ", "AR", "CA", "CO", "CT", "DE", "DC", "FL", "GA", "HI",
+		"ID", "IL", "IN", "IA", "KS", "KY", "LA", "ME", "MD", "MA", "MI", "MN",
+		"MS", "MO", "MT", "NE", "NV", "NH", "NJ", "NM", "NY", "NC", "ND", "OH",
+		"OK", "OR", "PA", "RI", "SC", "SD", "TN", "TX", "UT", "VT", "VA", "WA",
+		"WV", "WI", "WY", "PR", "GU", "VI", "AS", "MP",
+	},
+	CountryCA: {"AB", "BC", "MB", "NB", "NL", "NS", "NT", "NU", "ON", "PE", "QC", "SK", "YT"},
+	CountryAU: {"ACT", "NSW", "NT", "QLD", "SA", "TAS", "VIC", "WA"},
+}
+
+// ValidateFor returns an error wrapping ErrUnknownRegion if s is not a
+// known subdivision of country. Countries whose subdivisions are not listed
+// accept any value.
+func (s State) ValidateFor(country Country) error {
+	states, ok := knownStates[country]
+	if !ok {
+		return nil
+	}
+	for _, known := range states {
+		if s == known {
+			return nil
+		}
+	}
+	sorted := make([]string, len(states))
+	for i, st := range states {
+		sorted[i] = string(st)
+	}
+	sort.Strings(sorted)
+	return fmt.Errorf("%w: state %q is not a subdivision of %s (expected one of %s)",
+		ErrUnknownRegion, string(s), string(country), strings.Join(sorted, ", "))
+}
+
+// Region is a country supported by the plate decoder and its known
+// subdivisions. States is nil for countries whose subdivisions are not
+// validated.
+type Region struct {
+	Country Country
+	States  []State
+}
+
+// SupportedRegions returns the countries supported by the plate decoder,
+// sorted by code, with their known subdivisions.
+func SupportedRegions() []Region {
+	regions := make([]Region, 0, len(knownCountries))
+	for country := range knownCountries {
+		regions = append(regions, Region{Country: country, States: slices.Clone(knownStates[country])})
+	}
+	sort.Slice(regions, func(i, j int) bool { return regions[i].Country < regions[j].Country })
+	return regions
+}
+
+// ValidateRegion checks a country and optional state given as plain strings,
+// as in the PlateDecoder params, ignoring case. It returns an error wrapping
+// ErrUnknownRegion for an unsupported country or a state that is not a
+// subdivision of it.
+func ValidateRegion(country, state string) error {
+	co := Country(strings.ToUpper(strings.TrimSpace(country)))
+	if err := co.Validate(); err != nil {
+		return err
+	}
+	if state = strings.TrimSpace(state); state == "" {
+		return nil
+	}
+	return State(strings.ToUpper(state)).ValidateFor(co)
+}
+
+// PlateQuery is the input to PlateDecoderTyped.
+type PlateQuery struct {
+	Plate    string
+	Country  Country
+	State    State
+	District string
+	// AllowUnknownRegion sends Country and State as given, skipping local
+	// validation, for regions the package does not list yet.
+	AllowUnknownRegion bool
+}
+
+// PlateResult is the typed form of a PlateDecoder response.
+type PlateResult struct {
+	Plate       string
+	Country     Country
+	State       State
+	VIN         string
+	Description string
+	Make        string
+	Model       string
+	Year        string
+	// Raw is the decoded response body.
+	Raw map[string]any
+	// Err is set by BulkPlateDecoder when the lookup failed.
+	Err error
+}
+
+// PlateDecoderTyped is like PlateDecoder but validates the country and state
+// locally before the call, honors ctx, returns errors and decodes the
+// response into a PlateResult. Country defaults to US.
+func (c *Client) PlateDecoderTyped(ctx context.Context, q PlateQuery, opts ...CallOption) (*PlateResult, error) {
+	if q.Country == "" {
+		q.Country = CountryUS
+	}
+	params, err := q.Encode()
+	if err != nil {
+		return nil, err
+	}
+	raw, err := c.PlateDecoderContext(ctx, params, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &PlateResult{
+		Plate:       q.Plate,
+		Country:     q.Country,
+		State:       q.State,
+		VIN:         firstString(raw, "vin", "VIN"),
+		Description: firstString(raw, "description", "Description"),
+		Make:        firstString(raw, "make", "CarMake"),
+		Model:       firstString(raw, "model", "CarModel"),
+		Year:        firstString(raw, "year", "RegistrationYear", "registrationYear"),
+		Raw:         raw,
+	}, nil
+}
+
+// BulkPlateDecoder runs PlateDecoderTyped for every query with at most
+// concurrency lookups at once and returns the results in input order.
+// Identical queries, after plate normalization and ignoring case, are looked
+// up only once and share the result. Per-query failures are reported in
+// PlateResult.Err. Once ctx is cancelled no new lookups start; queries that
+// were not looked up carry ctx's error, which is also returned.
+func (c *Client) BulkPlateDecoder(ctx context.Context, queries []PlateQuery, concurrency int, opts ...CallOption) ([]PlateResult, error) {
+	ctx = ctxOrBackground(ctx)
+	// Group input indexes by query.
+	var keys []string
+	groups := map[string][]int{}
+	for i, q := range queries {
+		k := plateQueryKey(q)
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], i)
+	}
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+
+	out := make([]PlateResult, len(queries))
+	done := make([]bool, len(queries))
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, concurrency)
+	)
+launch:
+	for _, k := range keys {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break launch
+		}
+		wg.Add(1)
+		go func(idx []int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			q := queries[idx[0]]
+			res, err := c.PlateDecoderTyped(ctx, q, opts...)
+			if err != nil {
+				res = &PlateResult{Plate: q.Plate, Country: q.Country, State: q.State, Err: err}
+			}
+			mu.Lock()
+			for _, i := range idx {
+				out[i] = *res
+				out[i].Plate = queries[i].Plate
+				done[i] = true
+			}
+			mu.Unlock()
+		}(groups[k])
+	}
+	wg.Wait()
+
+	err := ctx.Err()
+	for i, ok := range done {
+		if !ok {
+			q := queries[i]
+			out[i] = PlateResult{Plate: q.Plate, Country: q.Country, State: q.State, Err: err}
+		}
+	}
+	return out, err
+}
+
+// plateQueryKey identifies queries that BulkPlateDecoder can share.
+func plateQueryKey(q PlateQuery) string {
+	country := strings.ToUpper(strings.TrimSpace(string(q.Country)))
+	if country == "" {
+		country = string(CountryUS)
+	}
+	plate, err := NormalizePlate(q.Plate, country)
+	if err != nil {
+		plate = strings.ToUpper(strings.TrimSpace(q.Plate))
+	}
+	return strings.Join([]string{
+		plate, country,
+		strings.ToUpper(strings.TrimSpace(string(q.State))),
+		strings.ToUpper(strings.TrimSpace(q.District)),
+		fmt.Sprint(q.AllowUnknownRegion),
+	}, "\x00")
+}
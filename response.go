@@ -0,0 +1,99 @@
+package carsxe
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Response is an API response with its metadata and undecoded body.
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	// FromCache reports whether the response was served from the client's
+	// cache rather than the network. Cached responses carry an empty Header.
+	FromCache bool
+	// Stale reports whether a cached response was past its TTL and served
+	// under WithStaleWhileRevalidate while being refreshed.
+	Stale bool
+	// Attempts is the number of HTTP attempts made, greater than 1 when
+	// WithRetry retried the call. It is 0 for cached responses.
+	Attempts int
+}
+
+// defaultMaxResponseBytes caps response bodies unless WithMaxResponseBytes
+// says otherwise.
+const defaultMaxResponseBytes = 10 << 20
+
+// maxDrainBytes bounds how much of an oversized body is read and discarded
+// so the connection can be reused; larger remainders close it instead.
+const maxDrainBytes = 64 << 10
+
+// ErrResponseTooLarge is returned when a response body exceeds the limit set
+// with WithMaxResponseBytes.
+var ErrResponseTooLarge = errors.New("carsxe: response too large")
+
+// WithMaxResponseBytes limits the size of response bodies, after
+// decompression, to n bytes (default 10 MiB). Larger responses fail with
+// ErrResponseTooLarge before anything is decoded.
+func WithMaxResponseBytes(n int64) Option {
+	return func(c *Client) {
+		if n > 0 {
+			c.maxRespBytes = n
+		}
+	}
+}
+
+// readBody reads body, the decoded form of raw, up to the response size
+// limit, so a compressed body cannot expand past it either. When the limit
+// is exceeded, a bounded amount of raw is drained before the caller closes
+// it.
+func (c *Client) readBody(raw io.Reader, body io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(http.MaxBytesReader(nil, io.NopCloser(body), c.maxRespBytes))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		io.CopyN(io.Discard, raw, maxDrainBytes)
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrResponseTooLarge, c.maxRespBytes)
+	}
+	if err != nil {
+		return data, fmt.Errorf("Failed to read response body: %w", err)
+	}
+	return data, nil
+}
+
+// Decode unmarshals the JSON body into v. An empty body leaves v untouched.
+func (r *Response) Decode(v any) error {
+	if len(r.Body) == 0 {
+		return nil
+	}
+	return json.Unmarshal(r.Body, v)
+}
+
+// RateLimitRemaining returns the X-RateLimit-Remaining header as an int.
+// It reports false when the header is missing or malformed, which is always
+// the case for cached responses.
+func (r *Response) RateLimitRemaining() (int, bool) {
+	n, err := strconv.Atoi(strings.TrimSpace(r.Header.Get("X-RateLimit-Remaining")))
+	return n, err == nil
+}
+
+// WithResponseInto copies the metadata and body of the response into dst
+// once the call completes, so callers of the map-based methods can inspect
+// the exact status code (for example 200, 204 or 206) and headers. dst is
+// left untouched when no response was received. Cached responses report
+// 200.
+func WithResponseInto(dst *Response) CallOption {
+	return func(co *callOptions) { co.response = dst }
+}
+
+// capture fills the WithResponseInto destination, if any, from resp.
+func (co *callOptions) capture(resp *Response) {
+	if co.response != nil && resp != nil {
+		*co.response = *resp
+	}
+}
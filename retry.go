@@ -0,0 +1,178 @@
+package carsxe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRetryDelay caps the computed backoff between attempts. A Retry-After
+// header sent by the server is honored even when it is longer.
+const maxRetryDelay = 30 * time.Second
+
+// WithRetry retries failed GET requests up to maxRetries times on 429, 500,
+// 502, 503 and 504 responses and on network errors, waiting baseDelay
+// doubled after each attempt, with jitter. A Retry-After header on 429 and
+// 503 responses takes precedence over the computed delay. Waiting stops as
+// soon as the request's context is done.
+//
+// POST endpoints such as VinOCR are only retried when the call is marked
+// with WithIdempotent. Every attempt goes through the client's rate and
+// concurrency limits and is logged and reported to Metrics; the number of
+// attempts is available in Response.Attempts and ErrorDetail.Attempts, and
+// an error returned after several attempts is a *RetryError.
+func WithRetry(maxRetries int, baseDelay time.Duration) Option {
+	return func(c *Client) {
+		if maxRetries < 0 {
+			maxRetries = 0
+		}
+		c.maxRetries = maxRetries
+		c.retryBaseDelay = baseDelay
+	}
+}
+
+// WithIdempotent marks a non-GET call as safe to retry under WithRetry.
+func WithIdempotent() CallOption {
+	return func(co *callOptions) { co.idempotent = true }
+}
+
+// RetryPolicy decides whether a failed attempt is retried and how long to
+// wait first. attempt starts at 1; resp is nil when no response was
+// received.
+type RetryPolicy func(attempt int, resp *Response, err error) (delay time.Duration, retry bool)
+
+// WithRetryPolicy replaces the default retry decision of WithRetry with p.
+// WithRetry still sets the maximum number of retries, and non-idempotent
+// calls, cancelled contexts and request bodies that cannot be replayed are
+// never retried.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) { c.retryPolicy = p }
+}
+
+// ExponentialBackoff is the default RetryPolicy: it retries 429, 500, 502,
+// 503 and 504 responses and network errors, waiting base doubled after each
+// attempt with jitter, capped at 30s. A Retry-After header on 429 and 503
+// responses takes precedence.
+func ExponentialBackoff(base time.Duration) RetryPolicy {
+	return func(attempt int, resp *Response, err error) (time.Duration, bool) {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			switch apiErr.StatusCode {
+			case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+				if resp != nil {
+					if d, ok := retryAfter(resp.Header); ok {
+						return d, true
+					}
+				}
+			case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+			default:
+				return 0, false
+			}
+		} else if !isTransient(err) {
+			return 0, false
+		}
+
+		if base <= 0 {
+			return 0, true
+		}
+		d := base << (attempt - 1)
+		if d <= 0 || d > maxRetryDelay {
+			d = maxRetryDelay
+		}
+		// Equal jitter keeps at least half the backoff while spreading out
+		// clients that failed together.
+		half := d / 2
+		return half + rand.N(half+1), true
+	}
+}
+
+// RetryError wraps the final error of a call that WithRetry attempted more
+// than once. errors.As still finds the underlying *APIError.
+type RetryError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("%v (after %d attempts)", e.Err, e.Attempts)
+}
+
+func (e *RetryError) Unwrap() error { return e.Err }
+
+// retryDelay reports whether the outcome of attempt (starting at 1) should
+// be retried and how long to wait first.
+func (c *Client) retryDelay(req *http.Request, co *callOptions, resp *Response, err error, attempt int) (time.Duration, bool) {
+	if err == nil || co.noRetry || attempt > c.maxRetries || req.Context().Err() != nil {
+		return 0, false
+	}
+	if req.Method != http.MethodGet && !co.idempotent {
+		return 0, false
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return 0, false
+	}
+	policy := c.retryPolicy
+	if policy == nil {
+		policy = ExponentialBackoff(c.retryBaseDelay)
+	}
+	return policy(attempt, resp, err)
+}
+
+// isTransient reports whether err is a network failure worth retrying.
+func isTransient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, ErrBodyReadTimeout) ||
+		strings.Contains(err.Error(), "unexpected EOF") ||
+		strings.Contains(err.Error(), "connection reset")
+}
+
+// retryAfter parses a Retry-After header given in seconds or as an HTTP
+// date.
+func retryAfter(h http.Header) (time.Duration, bool) {
+	v := strings.TrimSpace(h.Get("Retry-After"))
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
+
+// rewindRequest prepares req to be sent again, replacing a consumed body.
+func rewindRequest(req *http.Request) (*http.Request, error) {
+	if req.GetBody == nil {
+		return req, nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	req = req.Clone(req.Context())
+	req.Body = body
+	return req, nil
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
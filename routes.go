@@ -0,0 +1,124 @@
+package carsxe
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// endpointPaths maps the name of each endpoint the client's methods call to
+// its current path. The paths double as the endpoints' identifiers in
+// options such as WithEndpointCacheTTL and WithAllowedEndpoints, and keep
+// doing so when a call is routed elsewhere with WithEndpointOverride or
+// WithAPIVersion.
+var endpointPaths = map[string]string{
+	"specs":                     "specs",
+	"marketvalue":               "v2/marketvalue",
+	"history":                   "history",
+	"recalls":                   "v1/recalls",
+	"international-vin-decoder": "v1/international-vin-decoder",
+	"platedecoder":              "v2/platedecoder",
+	"platerecognition":          "platerecognition",
+	"vinocr":                    "v1/vinocr",
+	"ymm":                       "v1/ymm",
+	"images":                    "images",
+	"obdcodesdecoder":           "obdcodesdecoder",
+	"lien-theft":                "v1/lien-theft",
+}
+
+// versionPrefixRe matches the version segment of an endpoint path.
+var versionPrefixRe = regexp.MustCompile(`^v\d+/`)
+
+// canonicalEndpoint returns the path the client's methods use for endpoint,
+// given either its name ("marketvalue") or that path ("v2/marketvalue").
+// Other endpoints are returned as is.
+func canonicalEndpoint(endpoint string) string {
+	endpoint = strings.Trim(endpoint, "/")
+	if p, ok := endpointPaths[endpoint]; ok {
+		return p
+	}
+	return endpoint
+}
+
+// WithEndpointOverride sends calls to endpoint to path instead, e.g. to opt
+// into an API version the package does not use yet:
+//
+//	carsxe.WithEndpointOverride("marketvalue", "v3/marketvalue")
+//
+// endpoint is an endpoint's name or current path ("marketvalue" or
+// "v2/marketvalue"), or any path passed to GetContext. path is relative to
+// the base URL, or an absolute URL to route the endpoint through a gateway
+// of its own; the API key and source are still added to its query.
+//
+// Only the request URL changes: options keyed by endpoint, such as
+// WithEndpointCacheTTL, WithAllowedEndpoints and WithCostModel, and the
+// endpoint reported to logs, metrics and hooks keep using the current path.
+// An override takes precedence over WithAPIVersion.
+func WithEndpointOverride(endpoint, path string) Option {
+	return func(c *Client) {
+		if c.routes == nil {
+			c.routes = map[string]string{}
+		}
+		c.routes[canonicalEndpoint(endpoint)] = strings.TrimLeft(path, "/")
+	}
+}
+
+// WithAPIVersion sends calls to the given endpoints, or to every endpoint
+// the client's methods use when none are given, to that version of the API:
+// with version "v3", "v2/marketvalue" and "specs" become "v3/marketvalue"
+// and "v3/specs". An empty version selects the unversioned paths. Endpoints
+// are named as in WithEndpointOverride, which takes precedence.
+func WithAPIVersion(version string, endpoints ...string) Option {
+	version = strings.Trim(version, "/")
+	return func(c *Client) {
+		if len(endpoints) == 0 {
+			for _, p := range endpointPaths {
+				c.setEndpointVersion(p, version)
+			}
+			return
+		}
+		for _, e := range endpoints {
+			c.setEndpointVersion(canonicalEndpoint(e), version)
+		}
+	}
+}
+
+func (c *Client) setEndpointVersion(endpoint, version string) {
+	if c.apiVersions == nil {
+		c.apiVersions = map[string]string{}
+	}
+	p := versionPrefixRe.ReplaceAllString(endpoint, "")
+	if version != "" {
+		p = version + "/" + p
+	}
+	c.apiVersions[endpoint] = p
+}
+
+// EndpointPath returns the path, or absolute URL, that calls to endpoint
+// are sent to after WithEndpointOverride and WithAPIVersion. endpoint is
+// named as in WithEndpointOverride.
+func (c *Client) EndpointPath(endpoint string) string {
+	return c.endpointPath(canonicalEndpoint(endpoint))
+}
+
+// endpointPath resolves the path requests for endpoint, as passed by the
+// client's methods, are sent to.
+func (c *Client) endpointPath(endpoint string) string {
+	endpoint = strings.Trim(endpoint, "/")
+	if p, ok := c.routes[endpoint]; ok {
+		return p
+	}
+	if p, ok := c.apiVersions[endpoint]; ok {
+		return p
+	}
+	return endpoint
+}
+
+// endpointURL returns the URL, without query, of requests for endpoint.
+func (c *Client) endpointURL(endpoint string) string {
+	p := c.endpointPath(endpoint)
+	if u, err := url.Parse(p); err == nil && u.IsAbs() {
+		return p
+	}
+	return c.baseURL + "/" + p
+}
@@ -0,0 +1,80 @@
+package carsxe
+
+import (
+	"context"
+	"net/http"
+	"sync"
+)
+
+// WithSingleflight makes concurrent identical GET calls, with the same
+// endpoint and parameters, share a single request: the first caller sends
+// it and the others wait for its outcome instead of making billable
+// duplicates. Waiting callers stop waiting when their own context is done,
+// but share the first caller's error if its context is cancelled. Per-call
+// options such as WithCallHeader and WithCallTimeout are those of the first
+// caller.
+func WithSingleflight() Option {
+	return func(c *Client) { c.flights = &flightGroup{} }
+}
+
+// flightGroup deduplicates concurrent calls by key.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flight
+}
+
+type flight struct {
+	done chan struct{}
+	resp *Response
+	err  error
+}
+
+// do runs fn once for all concurrent callers with the same key. shared
+// reports whether the outcome came from another caller's fn; the Response
+// is then a copy.
+func (g *flightGroup) do(ctx context.Context, key string, fn func() (*Response, error)) (resp *Response, err error, shared bool) {
+	g.mu.Lock()
+	if f, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		select {
+		case <-f.done:
+		case <-ctx.Done():
+			return nil, ctx.Err(), true
+		}
+		if f.resp != nil {
+			cp := *f.resp
+			resp = &cp
+		}
+		return resp, f.err, true
+	}
+	if g.calls == nil {
+		g.calls = map[string]*flight{}
+	}
+	f := &flight{done: make(chan struct{})}
+	g.calls[key] = f
+	g.mu.Unlock()
+
+	defer func() {
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(f.done)
+	}()
+	f.resp, f.err = fn()
+	return f.resp, f.err, false
+}
+
+// execute implements doRequest below the interceptor chain, sharing
+// identical in-flight GETs under WithSingleflight.
+func (c *Client) execute(req *http.Request, endpoint string, co *callOptions) (*Response, error) {
+	if c.flights == nil || req.Method != http.MethodGet {
+		return c.send(req, endpoint, co)
+	}
+	resp, err, shared := c.flights.do(req.Context(), req.URL.String(), func() (*Response, error) {
+		return c.send(req, endpoint, co)
+	})
+	if shared {
+		co.capture(resp)
+	}
+	return resp, err
+}
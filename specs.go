@@ -0,0 +1,100 @@
+package carsxe
+
+import (
+	"context"
+	"strconv"
+)
+
+// SpecsResult is the typed form of a Specs response. Fields the API did not
+// return are left at their zero value; Raw keeps the full response.
+type SpecsResult struct {
+	VIN   string
+	Year  int
+	Make  string
+	Model string
+	Trim  string
+	Style string
+	// BodyStyle is the body style label. BodyStyleCode holds the numeric
+	// code the API returned when WithEnumDecoding translated it.
+	BodyStyle     string
+	BodyStyleCode string
+	Type          string
+	Doors         int
+	MadeIn        string
+	// Engine holds the engine and transmission details; see EngineSpecs.
+	Engine         *EngineResult
+	CityMPG        float64
+	HighwayMPG     float64
+	MSRP           float64
+	StandardSeats  int
+	CurbWeightLbs  float64
+	Colors         []string
+	AssemblyPlant  string
+	SequenceNumber string
+	// Measurements holds the measurements converted by WithUnits, keyed by
+	// attribute name (e.g. "city_mileage"); nil without WithUnits.
+	Measurements map[string]Measurement
+	// Raw is the decoded response body.
+	Raw map[string]any
+}
+
+// SpecsTyped is like Specs but honors ctx, returns errors and decodes the
+// response into a SpecsResult. Values are parsed leniently: numbers sent as
+// strings with units are accepted, and missing, null or unexpected values
+// leave the field at its zero value.
+func (c *Client) SpecsTyped(ctx context.Context, params map[string]string, opts ...CallOption) (*SpecsResult, error) {
+	raw, err := c.SpecsContext(ctx, params, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return c.newSpecsResult(params["vin"], raw), nil
+}
+
+// newSpecsResult maps a Specs response onto SpecsResult.
+func (c *Client) newSpecsResult(vin string, raw map[string]any) *SpecsResult {
+	engine := c.newEngineResult(vin, raw)
+	attrs := engine.Raw
+	r := &SpecsResult{
+		VIN:    engine.VIN,
+		Make:   toString(attrs["make"]),
+		Model:  toString(attrs["model"]),
+		Trim:   toString(attrs["trim"]),
+		Style:  toString(attrs["style"]),
+		Type:   toString(attrs["type"]),
+		MadeIn: toString(attrs["made_in"]),
+		Engine: engine,
+		Raw:    raw,
+	}
+	r.Year, _ = toInt(attrs["year"])
+	r.Doors, _ = toInt(attrs["doors"])
+	r.CityMPG, _ = toFloat(firstValue(attrs, "city_mileage", "city_mpg"))
+	r.HighwayMPG, _ = toFloat(firstValue(attrs, "highway_mileage", "highway_mpg"))
+	r.MSRP, _ = toFloat(firstValue(attrs, "manufacturer_suggested_retail_price", "msrp"))
+	r.StandardSeats, _ = toInt(attrs["standard_seating"])
+	r.CurbWeightLbs, _ = toFloat(attrs["curb_weight"])
+	r.BodyStyle, r.BodyStyleCode = c.enumValue("body_style", firstValue(attrs, "body_style", "body_class"))
+
+	for _, item := range asSlice(raw["colors"]) {
+		name := toString(item)
+		if m := asMap(item); m != nil {
+			name = toString(m["name"])
+		}
+		if name != "" {
+			r.Colors = append(r.Colors, name)
+		}
+	}
+	if info, ok := DecodeVIN(r.VIN); ok {
+		r.AssemblyPlant = info.AssemblyPlant
+		r.SequenceNumber = info.SequenceNumber
+	}
+	if c.units != UnitsAsReturned {
+		r.Measurements = ConvertSpecs(attrs, c.units)
+		// Fall back to the displacement parsed from the engine description.
+		if _, ok := r.Measurements["displacement"]; !ok && engine.DisplacementLiters > 0 {
+			m, _ := convertMeasurement(strconv.FormatFloat(engine.DisplacementLiters, 'f', -1, 64)+" L", displacement, c.units)
+			m.Original = engine.Engine
+			r.Measurements["displacement"] = m
+		}
+	}
+	return r
+}
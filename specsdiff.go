@@ -0,0 +1,170 @@
+package carsxe
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SpecsDiff is the structured difference between two Specs responses, as
+// returned by CompareSpecs. Entries are sorted by path.
+type SpecsDiff struct {
+	// Added lists the values only present in the deep response.
+	Added []DiffEntry
+	// Removed lists the values only present in the basic response.
+	Removed []DiffEntry
+	// Changed lists the values present in both with different contents.
+	Changed []DiffEntry
+}
+
+// DiffEntry is one difference of a SpecsDiff. Path names the value with
+// dots between object keys and [i] for array elements, e.g.
+// "attributes.engine" or "colors[0].name".
+type DiffEntry struct {
+	Path  string
+	Basic any
+	Deep  any
+}
+
+// Empty reports whether the responses were identical.
+func (d SpecsDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// CompareSpecs compares a standard Specs response with a deepdata one,
+// descending into nested objects and arrays. Null and empty-string values
+// count as absent, so a field the basic response left blank and the deep
+// one filled in is reported as added, and values with the same text, such
+// as "2012" and 2012, count as equal.
+func CompareSpecs(basic, deep map[string]any) SpecsDiff {
+	var d SpecsDiff
+	d.compare("", basic, deep)
+	return d
+}
+
+func (d *SpecsDiff) compare(path string, basic, deep any) {
+	switch {
+	case isBlank(basic) && isBlank(deep):
+		return
+	case isBlank(basic):
+		d.Added = append(d.Added, DiffEntry{Path: path, Deep: deep})
+		return
+	case isBlank(deep):
+		d.Removed = append(d.Removed, DiffEntry{Path: path, Basic: basic})
+		return
+	}
+	if bm, dm := asMap(basic), asMap(deep); bm != nil && dm != nil {
+		union := maps.Clone(bm)
+		maps.Copy(union, dm)
+		for _, k := range slices.Sorted(maps.Keys(union)) {
+			p := k
+			if path != "" {
+				p = path + "." + k
+			}
+			d.compare(p, bm[k], dm[k])
+		}
+		return
+	}
+	if bs, ds := asSlice(basic), asSlice(deep); bs != nil && ds != nil {
+		for i := range max(len(bs), len(ds)) {
+			var b, v any
+			if i < len(bs) {
+				b = bs[i]
+			}
+			if i < len(ds) {
+				v = ds[i]
+			}
+			d.compare(path+"["+strconv.Itoa(i)+"]", b, v)
+		}
+		return
+	}
+	if !reflect.DeepEqual(basic, deep) && !sameScalar(basic, deep) {
+		d.Changed = append(d.Changed, DiffEntry{Path: path, Basic: basic, Deep: deep})
+	}
+}
+
+// sameScalar reports whether a and b are plain values with the same text,
+// such as "2012" and 2012.
+func sameScalar(a, b any) bool {
+	if asMap(a) != nil || asMap(b) != nil || asSlice(a) != nil || asSlice(b) != nil {
+		return false
+	}
+	sa := toString(a)
+	return sa != "" && sa == toString(b)
+}
+
+// isBlank reports whether v is missing, null or an empty string.
+func isBlank(v any) bool {
+	if v == nil {
+		return true
+	}
+	s, ok := v.(string)
+	return ok && strings.TrimSpace(s) == ""
+}
+
+// defaultSpecsRequired lists the paths SpecsWithFallback checks when none
+// are given.
+var defaultSpecsRequired = []string{
+	"attributes.year", "attributes.make", "attributes.model", "attributes.trim", "attributes.engine",
+}
+
+// SpecsWithFallback decodes a VIN with a standard Specs call and repeats it
+// with deepdata when any of the required paths (in CompareSpecs notation,
+// e.g. "attributes.engine") is missing, null or empty. A nil required
+// checks year, make, model, trim and engine. deep reports whether the
+// deepdata result was returned. If the deepdata call fails, the standard
+// result is returned along with the error.
+func (c *Client) SpecsWithFallback(ctx context.Context, params map[string]string, required []string, opts ...CallOption) (res *SpecsResult, deep bool, err error) {
+	if params["deepdata"] != "" && params["deepdata"] != "false" && params["deepdata"] != "0" {
+		res, err = c.SpecsTyped(ctx, params, opts...)
+		return res, err == nil, err
+	}
+	res, err = c.SpecsTyped(ctx, params, opts...)
+	if err != nil {
+		return nil, false, err
+	}
+	if required == nil {
+		required = defaultSpecsRequired
+	}
+	if !slices.ContainsFunc(required, func(p string) bool { return isBlank(lookupPath(res.Raw, p)) }) {
+		return res, false, nil
+	}
+	deepParams := maps.Clone(params)
+	deepParams["deepdata"] = "true"
+	deepRes, err := c.SpecsTyped(ctx, deepParams, opts...)
+	if err != nil {
+		return res, false, fmt.Errorf("carsxe: deepdata fallback: %w", err)
+	}
+	return deepRes, true, nil
+}
+
+// lookupPath returns the value at path (in CompareSpecs notation) in m, or
+// nil.
+func lookupPath(m map[string]any, path string) any {
+	var cur any = m
+	for _, part := range strings.Split(path, ".") {
+		name, rest, _ := strings.Cut(part, "[")
+		if name != "" {
+			obj := asMap(cur)
+			if obj == nil {
+				return nil
+			}
+			cur = obj[name]
+		}
+		for rest != "" {
+			idx, after, ok := strings.Cut(rest, "]")
+			i, err := strconv.Atoi(idx)
+			arr := asSlice(cur)
+			if !ok || err != nil || i < 0 || i >= len(arr) {
+				return nil
+			}
+			cur = arr[i]
+			rest = strings.TrimPrefix(after, "[")
+		}
+	}
+	return cur
+}
@@ -0,0 +1,115 @@
+package carsxe
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WithStaleWhileRevalidate keeps cached responses for up to d after their
+// TTL has passed. A call finding such a stale entry gets it immediately
+// (Response.Stale is set) while a fresh copy is fetched in the background
+// and stored for later calls. Only one refresh per cache entry runs at a
+// time. It has no effect without WithCache, or on entries stored without an
+// expiry because their TTL is zero.
+func WithStaleWhileRevalidate(d time.Duration) Option {
+	return func(c *Client) {
+		c.staleWindow = d
+		c.ensureRevalidator()
+	}
+}
+
+// WithEndpointStaleWhileRevalidate is like WithStaleWhileRevalidate for
+// endpoint only (written as in the API paths, e.g. "specs"), overriding the
+// client-wide window. A d of zero or less turns it off for that endpoint.
+func WithEndpointStaleWhileRevalidate(endpoint string, d time.Duration) Option {
+	return func(c *Client) {
+		if c.endpointStale == nil {
+			c.endpointStale = map[string]time.Duration{}
+		}
+		c.endpointStale[strings.Trim(endpoint, "/")] = d
+		c.ensureRevalidator()
+	}
+}
+
+func (c *Client) ensureRevalidator() {
+	if c.revalidating == nil {
+		c.revalidating = &revalidator{keys: map[string]bool{}}
+	}
+}
+
+// staleWindowFor returns how long entries from endpoint may be served past
+// their TTL.
+func (c *Client) staleWindowFor(endpoint string) time.Duration {
+	if d, ok := c.endpointStale[strings.Trim(endpoint, "/")]; ok {
+		return max(d, 0)
+	}
+	return max(c.staleWindow, 0)
+}
+
+// staleMagic prefixes cache entries written under stale-while-revalidate,
+// followed by the fresh-until time in Unix nanoseconds and the body. JSON
+// bodies never start with it.
+var staleMagic = []byte("\x00cxswr1")
+
+// wrapStale encodes body with the time until which it is fresh.
+func wrapStale(body []byte, freshUntil time.Time) []byte {
+	out := make([]byte, 0, len(staleMagic)+8+len(body))
+	out = append(out, staleMagic...)
+	out = binary.BigEndian.AppendUint64(out, uint64(freshUntil.UnixNano()))
+	return append(out, body...)
+}
+
+// unwrapStale decodes an entry written by wrapStale, reporting whether it
+// is past its fresh-until time. Other entries are returned as fresh.
+func unwrapStale(val []byte) (body []byte, stale bool) {
+	if !bytes.HasPrefix(val, staleMagic) || len(val) < len(staleMagic)+8 {
+		return val, false
+	}
+	n := len(staleMagic)
+	freshUntil := time.Unix(0, int64(binary.BigEndian.Uint64(val[n:n+8])))
+	return val[n+8:], time.Now().After(freshUntil)
+}
+
+// revalidator tracks the cache entries being refreshed in the background.
+type revalidator struct {
+	mu   sync.Mutex
+	keys map[string]bool
+}
+
+// start reports whether a refresh of key may begin, claiming it if so.
+func (r *revalidator) start(key string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.keys[key] {
+		return false
+	}
+	r.keys[key] = true
+	return true
+}
+
+func (r *revalidator) finish(key string) {
+	r.mu.Lock()
+	delete(r.keys, key)
+	r.mu.Unlock()
+}
+
+// revalidate refreshes the cache entry for req in the background, detached
+// from the caller's cancellation, unless a refresh is already running.
+func (c *Client) revalidate(req *http.Request, endpoint string, co *callOptions, cacheKey string) {
+	if !c.revalidating.start(cacheKey) {
+		return
+	}
+	bg := req.Clone(context.WithoutCancel(req.Context()))
+	refresh := *co
+	refresh.refreshCache = true
+	refresh.response = nil
+	go func() {
+		defer c.revalidating.finish(cacheKey)
+		c.send(bg, endpoint, &refresh)
+	}()
+}
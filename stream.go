@@ -0,0 +1,168 @@
+package carsxe
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"time"
+)
+
+// ErrStopStream can be returned by a GetNDJSON callback to stop reading the
+// stream early; GetNDJSON then returns nil.
+var ErrStopStream = errors.New("carsxe: stop stream")
+
+// errCallback marks a stream stopped by its callback's error.
+var errCallback = errors.New("carsxe: stream stopped by callback")
+
+// ndjsonAccept is the Accept header GetNDJSON sends unless one is set.
+const ndjsonAccept = "application/x-ndjson, application/json;q=0.9"
+
+// GetNDJSON performs a GET request to an endpoint answering with
+// newline-delimited JSON, such as a bulk export, and calls fn with each
+// record as it is read:
+//
+//	err := client.GetNDJSON(ctx, "v1/export", params, func(rec json.RawMessage) error {
+//		var v struct{ VIN string `json:"vin"` }
+//		if err := json.Unmarshal(rec, &v); err != nil {
+//			return err
+//		}
+//		return store(ctx, v.VIN)
+//	})
+//
+// The body is never held in memory as a whole: the next record is only read
+// once fn returns, so a slow fn slows the download down rather than letting
+// records pile up. Blank lines are skipped, and each record must be a single
+// JSON value no larger than the WithMaxResponseBytes limit.
+//
+// If fn returns an error, reading stops and GetNDJSON returns that error, or
+// nil for ErrStopStream. Non-2xx responses are reported as *APIError like
+// other calls. The call goes through the client's limits, circuit breaker
+// and hooks, but it is never cached, retried or hedged, interceptors and
+// WithSingleflight do not apply, and WithBodyReadTimeout does not limit how
+// long the stream may take: bound it with ctx or WithCallTimeout instead.
+func (c *Client) GetNDJSON(ctx context.Context, endpoint string, params map[string]string, fn func(json.RawMessage) error, opts ...CallOption) error {
+	values := valuesOf(params)
+	if err := c.checkParams(endpoint, values); err != nil {
+		return err
+	}
+	req, err := c.newRequest(ctx, http.MethodGet, endpoint, values, nil)
+	if err != nil {
+		return err
+	}
+	co := newCallOptions(opts)
+	applyQuery(req, co)
+	req.Header.Set("Accept", ndjsonAccept) // WithHeaders and WithCallHeader take precedence
+	req, cancel, err := c.beginCall(req, endpoint, co)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	release, err := c.acquireSerial(req.Context())
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	a, err := c.reserveAttempt(req, endpoint, co)
+	if err != nil {
+		return err
+	}
+	req = a.req
+	var fnErr error
+	start := time.Now()
+	resp, err := c.roundTripNDJSON(a.signed, endpoint, func(rec json.RawMessage) error {
+		if fnErr = fn(rec); fnErr != nil {
+			return errCallback
+		}
+		return nil
+	})
+	if errors.Is(err, errCallback) {
+		err = nil // the API delivered; the caller stopped
+	}
+	a.finish(resp, err)
+	c.observeAttempt(req, endpoint, resp, time.Since(start), 1, 0, false, err)
+	c.endCall(req, endpoint, co, resp, start, 1, err)
+	if err != nil {
+		return err
+	}
+	if errors.Is(fnErr, ErrStopStream) {
+		return nil
+	}
+	return fnErr
+}
+
+// roundTripNDJSON sends req and passes each record of the response to fn,
+// returning fn's first error. The returned Response has a Body only when
+// the response was an error.
+func (c *Client) roundTripNDJSON(req *http.Request, endpoint string, fn func(json.RawMessage) error) (*Response, error) {
+	if ae := acceptEncoding(); ae != "" && req.Header.Get("Accept-Encoding") == "" {
+		req.Header.Set("Accept-Encoding", ae)
+	}
+	c.runRequestHooks(req)
+	start := time.Now()
+	httpResp, err := c.httpClient.Do(req)
+	if err != nil {
+		c.runResponseHooks(req, nil, nil, time.Since(start))
+		return nil, fmt.Errorf("HTTP request failed: %w", c.sanitizeError(err))
+	}
+	defer httpResp.Body.Close()
+
+	resp := &Response{StatusCode: httpResp.StatusCode, Header: httpResp.Header}
+	defer func() {
+		if len(c.responseHooks) > 0 {
+			c.runResponseHooks(req, httpResp, resp.Body, time.Since(start))
+		}
+	}()
+	body, _, err := responseBody(httpResp)
+	if err != nil {
+		return resp, err
+	}
+	ct := httpResp.Header.Get("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(ct)
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 || mediaType == "text/html" {
+		if resp.Body, err = c.readBody(httpResp.Body, body); err != nil {
+			return resp, err
+		}
+		ctErr := c.checkContentType(httpResp.StatusCode, ct, resp.Body)
+		if err := c.responseError(req, endpoint, httpResp, resp.Body, ctErr); err != nil {
+			return resp, err
+		}
+		if ctErr != nil {
+			return resp, ctErr
+		}
+		body = bytes.NewReader(resp.Body)
+	}
+
+	sc := bufio.NewScanner(body)
+	sc.Buffer(make([]byte, 0, min(64<<10, c.maxRespBytes)), int(c.maxRespBytes))
+	n := 0
+	for sc.Scan() {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		n++
+		var rec json.RawMessage // a copy, so fn may keep it
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return resp, fmt.Errorf("Failed to decode NDJSON record %d: %w (record=%s)", n, err, truncate(string(line), maxErrorBodyLen))
+		}
+		if err := checkJSONDepth(rec, c.maxJSONDepth); err != nil {
+			return resp, fmt.Errorf("NDJSON record %d: %w", n, err)
+		}
+		if err := fn(rec); err != nil {
+			return resp, err
+		}
+	}
+	if err := sc.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			return resp, fmt.Errorf("%w: NDJSON record %d is more than %d bytes", ErrResponseTooLarge, n+1, c.maxRespBytes)
+		}
+		return resp, fmt.Errorf("Failed to read response body: %w", err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,49 @@
+package carsxe
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// ErrBodyReadTimeout is returned when reading a response body takes longer
+// than the limit set with WithBodyReadTimeout.
+var ErrBodyReadTimeout = errors.New("carsxe: timed out reading response body")
+
+// WithBodyReadTimeout limits how long reading a response body may take,
+// measured from the moment the response headers arrive. It is independent of
+// the overall http.Client timeout, so a client can wait patiently for a
+// connection while refusing to sit on a slowly streamed body.
+func WithBodyReadTimeout(d time.Duration) Option {
+	return func(c *Client) { c.bodyReadTimeout = d }
+}
+
+// bodyDeadline prepares req for a body-read deadline. Call start once the
+// headers have arrived and stop once the body is read; stop reports whether
+// the deadline fired.
+func (c *Client) bodyDeadline(req *http.Request) (out *http.Request, start func(), stop func() bool) {
+	if c.bodyReadTimeout <= 0 {
+		return req, func() {}, func() bool { return false }
+	}
+	ctx, cancel := context.WithCancel(req.Context())
+	var (
+		fired atomic.Bool
+		timer *time.Timer
+	)
+	start = func() {
+		timer = time.AfterFunc(c.bodyReadTimeout, func() {
+			fired.Store(true)
+			cancel()
+		})
+	}
+	stop = func() bool {
+		if timer != nil {
+			timer.Stop()
+		}
+		cancel()
+		return fired.Load()
+	}
+	return req.WithContext(ctx), start, stop
+}
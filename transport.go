@@ -0,0 +1,184 @@
+package carsxe
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RoundTripperFunc adapts a function to the http.RoundTripper interface.
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req).
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+// Middleware wraps an http.RoundTripper with additional behavior.
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// WithRoundTripper installs existing http.RoundTripper middleware around the
+// client's transport. The first middleware is the outermost one.
+//
+// Middleware sits below the client's built-in features: caching, priority
+// scheduling, rate and concurrency limits, logging and metrics all happen
+// before a request reaches the chain, so the chain sees exactly one call per
+// HTTP attempt that actually goes out. The *http.Client passed to
+// WithHTTPClient is not modified; the client works on a copy.
+func WithRoundTripper(mw ...Middleware) Option {
+	return func(c *Client) { c.middleware = append(c.middleware, mw...) }
+}
+
+// WithDialRetry retries a failed connection attempt (DNS lookup and dial)
+// up to retries times, waiting delay between tries, so a connection is
+// tried at most retries+1 times; zero or less turns retrying off. Dial
+// retries happen inside a single request and are separate from any
+// request-level retry, so brief network blips are absorbed quickly. It
+// requires the client's transport to be an *http.Transport (the default);
+// other transports are left untouched.
+func WithDialRetry(retries int, delay time.Duration) Option {
+	return func(c *Client) {
+		c.dialRetries = retries
+		c.dialDelay = delay
+	}
+}
+
+// WithProxy sends requests through the proxy at u instead of the one named
+// by the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables. A nil u
+// disables proxying altogether. It requires the client's transport to be an
+// *http.Transport (the default).
+func WithProxy(u *url.URL) Option {
+	return func(c *Client) {
+		c.proxySet = true
+		c.proxyURL = u
+	}
+}
+
+// WithTLSConfig uses cfg for TLS connections to the API, e.g. to trust a
+// corporate CA bundle through cfg.RootCAs. It requires the client's
+// transport to be an *http.Transport (the default).
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(c *Client) { c.tlsConfig = cfg }
+}
+
+// WithTransport sends requests through rt in place of the default
+// transport, keeping the client's timeout and other settings. Unlike
+// WithHTTPClient it composes with WithTimeout in either order. WithProxy,
+// WithTLSConfig, WithDialRetry and WithResponseHeaderTimeout apply only when
+// rt is an *http.Transport, which is copied rather than modified.
+//
+// The default transport is http.DefaultTransport, which keeps connections
+// alive and pools up to 100 idle connections.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) {
+		hc := *c.httpClient
+		hc.Transport = rt
+		c.httpClient = &hc
+	}
+}
+
+// WithResponseHeaderTimeout abandons a request when the server accepts the
+// connection but sends no response headers within d. Unlike the overall
+// client timeout it does not limit how long the body takes to download, so it
+// suits workers fetching large images that must not hang on dead
+// connections. It requires the client's transport to be an *http.Transport
+// (the default).
+func WithResponseHeaderTimeout(d time.Duration) Option {
+	return func(c *Client) { c.responseHeaderTimeout = d }
+}
+
+// buildTransport applies transport tuning and middleware to a copy of the
+// HTTP client.
+func (c *Client) buildTransport() {
+	rt := c.httpClient.Transport
+	changed := false
+	if c.needsTransportTuning() {
+		if t := cloneTransport(rt); t != nil {
+			c.tuneTransport(t)
+			rt, changed = t, true
+		}
+	}
+	if len(c.middleware) > 0 {
+		if rt == nil {
+			rt = http.DefaultTransport
+		}
+		for i := len(c.middleware) - 1; i >= 0; i-- {
+			rt = c.middleware[i](rt)
+		}
+		changed = true
+	}
+	if changed {
+		hc := *c.httpClient
+		hc.Transport = rt
+		c.httpClient = &hc
+	}
+}
+
+// needsTransportTuning reports whether any option requires changes to the
+// underlying *http.Transport.
+func (c *Client) needsTransportTuning() bool {
+	return c.dialRetries > 0 || c.responseHeaderTimeout > 0 || c.proxySet || c.tlsConfig != nil
+}
+
+// tuneTransport applies transport-level options to t.
+func (c *Client) tuneTransport(t *http.Transport) {
+	if c.dialRetries > 0 {
+		t.DialContext = retryDial(t.DialContext, c.dialRetries, c.dialDelay)
+	}
+	if c.responseHeaderTimeout > 0 {
+		t.ResponseHeaderTimeout = c.responseHeaderTimeout
+	}
+	if c.proxySet {
+		t.Proxy = nil
+		if c.proxyURL != nil {
+			t.Proxy = http.ProxyURL(c.proxyURL)
+		}
+	}
+	if c.tlsConfig != nil {
+		t.TLSClientConfig = c.tlsConfig.Clone()
+	}
+}
+
+// cloneTransport returns a copy of rt (the default transport when nil) that
+// can be tuned, or nil if rt is not an *http.Transport.
+func cloneTransport(rt http.RoundTripper) *http.Transport {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	t, ok := rt.(*http.Transport)
+	if !ok {
+		return nil
+	}
+	return t.Clone()
+}
+
+// dialFunc matches http.Transport.DialContext.
+type dialFunc func(ctx context.Context, network, addr string) (net.Conn, error)
+
+// retryDial wraps dial so a failed dial is retried up to retries times.
+func retryDial(dial dialFunc, retries int, delay time.Duration) dialFunc {
+	if dial == nil {
+		dial = (&net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}).DialContext
+	}
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		var err error
+		for i := 0; i <= retries; i++ {
+			if i > 0 {
+				select {
+				case <-time.After(delay):
+				case <-ctx.Done():
+					return nil, err
+				}
+			}
+			var conn net.Conn
+			if conn, err = dial(ctx, network, addr); err == nil {
+				return conn, nil
+			}
+			if ctx.Err() != nil {
+				return nil, err
+			}
+		}
+		return nil, err
+	}
+}
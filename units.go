@@ -0,0 +1,209 @@
+package carsxe
+
+import (
+	"math"
+	"regexp"
+	"strings"
+)
+
+// UnitSystem selects the units of the measurements in SpecsResult.
+type UnitSystem int
+
+// Unit systems. The zero value leaves values as the API returned them.
+const (
+	UnitsAsReturned UnitSystem = iota
+	Metric
+	Imperial
+)
+
+func (u UnitSystem) String() string {
+	switch u {
+	case Metric:
+		return "metric"
+	case Imperial:
+		return "imperial"
+	}
+	return "as-returned"
+}
+
+// WithUnits converts the recognized measurements of Specs responses (fuel
+// economy, weights, dimensions, fuel capacity and engine displacement) into
+// u and reports them in SpecsResult.Measurements. The other SpecsResult
+// fields and Raw are not changed. See ConvertSpecs.
+func WithUnits(u UnitSystem) Option {
+	return func(c *Client) { c.units = u }
+}
+
+// Measurement is a converted spec value.
+type Measurement struct {
+	Value float64
+	// Unit is the unit of Value: "L/100km", "mpg", "km/L", "kg", "lb",
+	// "mm", "cm", "in", "L", "gal", "cc" or "cu in". With Metric or
+	// Imperial it is one of that system's units: "L/100km", "kg", "mm" and
+	// "L", or "mpg", "lb", "in", "gal" and "cu in"; with UnitsAsReturned it
+	// is the unit the value was read in, which may be any of them.
+	Unit string
+	// Original is the value as returned by the API, e.g. "20 miles/gallon",
+	// and OriginalUnit the unit it was read in.
+	Original     string
+	OriginalUnit string
+	// Converted reports whether Value differs in unit from Original.
+	Converted bool
+}
+
+// quantity is the kind of a measured spec field.
+type quantity int
+
+const (
+	fuelEconomy quantity = iota
+	weight
+	length
+	volume
+	displacement
+)
+
+// unitFields maps the Specs attributes converted by ConvertSpecs to their
+// quantity.
+var unitFields = map[string]quantity{
+	"city_mileage":                fuelEconomy,
+	"highway_mileage":             fuelEconomy,
+	"combined_mileage":            fuelEconomy,
+	"curb_weight":                 weight,
+	"gross_vehicle_weight_rating": weight,
+	"overall_length":              length,
+	"overall_width":               length,
+	"overall_height":              length,
+	"wheelbase_length":            length,
+	"front_track":                 length,
+	"rear_track":                  length,
+	"ground_clearance":            length,
+	"fuel_capacity":               volume,
+	"fuel_tank_capacity":          volume,
+	"engine_size":                 displacement,
+	"displacement":                displacement,
+}
+
+// unitPatterns recognize the unit written after a value, per quantity, with
+// the unit assumed when none is written listed first.
+var unitPatterns = map[quantity][]struct {
+	unit string
+	re   *regexp.Regexp
+}{
+	fuelEconomy: {
+		{"mpg", regexp.MustCompile(`miles?\s*/\s*gal|\bmpg\b`)},
+		{"L/100km", regexp.MustCompile(`l\s*/\s*100\s*km`)},
+		{"km/L", regexp.MustCompile(`km\s*/\s*l\b`)},
+	},
+	weight: {
+		{"lb", regexp.MustCompile(`\blbs?\b|pounds?`)},
+		{"kg", regexp.MustCompile(`\bkgs?\b|kilograms?`)},
+	},
+	length: {
+		{"in", regexp.MustCompile(`\bin\b|inch|"`)},
+		{"mm", regexp.MustCompile(`\bmm\b|millimet`)},
+		{"cm", regexp.MustCompile(`\bcm\b|centimet`)},
+	},
+	volume: {
+		{"gal", regexp.MustCompile(`\bgal|gallons?`)},
+		{"L", regexp.MustCompile(`\bl\b|lit(?:er|re)s?`)},
+	},
+	displacement: {
+		{"L", regexp.MustCompile(`\bl\b|lit(?:er|re)s?`)},
+		{"cc", regexp.MustCompile(`\bcc\b|cm3|cm³`)},
+		{"cu in", regexp.MustCompile(`\bci\b|cu\.?\s*in|cubic inch`)},
+	},
+}
+
+// toMetric converts a value in unit to the metric unit of its quantity.
+var toMetric = map[string]func(float64) float64{
+	"mpg":     func(v float64) float64 { return 235.214583 / v },
+	"L/100km": func(v float64) float64 { return v },
+	"km/L":    func(v float64) float64 { return 100 / v },
+	"lb":      func(v float64) float64 { return v * 0.45359237 },
+	"kg":      func(v float64) float64 { return v },
+	"in":      func(v float64) float64 { return v * 25.4 },
+	"mm":      func(v float64) float64 { return v },
+	"cm":      func(v float64) float64 { return v * 10 },
+	"gal":     func(v float64) float64 { return v * 3.785411784 },
+	"L":       func(v float64) float64 { return v },
+	"cc":      func(v float64) float64 { return v / 1000 },
+	"cu in":   func(v float64) float64 { return v * 0.016387064 },
+}
+
+// units holds the metric and imperial unit of each quantity and the
+// conversion from metric to imperial.
+var units = map[quantity]struct {
+	metric, imperial string
+	toImperial       func(float64) float64
+}{
+	fuelEconomy:  {"L/100km", "mpg", func(v float64) float64 { return 235.214583 / v }},
+	weight:       {"kg", "lb", func(v float64) float64 { return v / 0.45359237 }},
+	length:       {"mm", "in", func(v float64) float64 { return v / 25.4 }},
+	volume:       {"L", "gal", func(v float64) float64 { return v / 3.785411784 }},
+	displacement: {"L", "cu in", func(v float64) float64 { return v / 0.016387064 }},
+}
+
+// ConvertSpecs converts the measurements found in a Specs attributes object
+// (fuel economy, weights, dimensions, fuel capacity and engine
+// displacement) into u, keyed by attribute name. Units are read from the
+// values, e.g. "20 miles/gallon" or "4056 lbs"; values without one are
+// taken to be in the units the API uses for US vehicles. With
+// UnitsAsReturned the values are parsed but not converted. attrs is not
+// modified.
+func ConvertSpecs(attrs map[string]any, u UnitSystem) map[string]Measurement {
+	out := map[string]Measurement{}
+	for field, q := range unitFields {
+		v, ok := attrs[field]
+		if !ok || isBlank(v) {
+			continue
+		}
+		if m, ok := convertMeasurement(v, q, u); ok {
+			out[field] = m
+		}
+	}
+	return out
+}
+
+// convertMeasurement parses v as a quantity q and converts it into u.
+func convertMeasurement(v any, q quantity, u UnitSystem) (Measurement, bool) {
+	n, ok := toFloat(v)
+	if !ok || n <= 0 {
+		return Measurement{}, false
+	}
+	original := toString(v)
+	from := detectUnit(original, q)
+	m := Measurement{Value: n, Unit: from, Original: original, OriginalUnit: from}
+	sys := units[q]
+	var to string
+	switch u {
+	case Metric:
+		to = sys.metric
+	case Imperial:
+		to = sys.imperial
+	default:
+		return m, true
+	}
+	if to == from {
+		return m, true
+	}
+	metric := toMetric[from](n)
+	if to == sys.imperial {
+		metric = sys.toImperial(metric)
+	}
+	m.Value = math.Round(metric*100) / 100
+	m.Unit = to
+	m.Converted = true
+	return m, true
+}
+
+// detectUnit returns the unit written in s for quantity q, or its default.
+func detectUnit(s string, q quantity) string {
+	s = strings.ToLower(s)
+	patterns := unitPatterns[q]
+	for _, p := range patterns {
+		if p.re.MatchString(s) {
+			return p.unit
+		}
+	}
+	return patterns[0].unit
+}
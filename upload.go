@@ -0,0 +1,189 @@
+package carsxe
+
+import (
+	"bufio"
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"path/filepath"
+	"sync"
+)
+
+// defaultMaxUploadSize is the largest image the FromReader methods send
+// unless WithMaxUploadSize says otherwise.
+const defaultMaxUploadSize = 10 << 20
+
+// ErrUploadTooLarge is returned when an image exceeds the maximum upload
+// size.
+var ErrUploadTooLarge = errors.New("carsxe: upload too large")
+
+// WithMaxUploadSize sets the largest image, in bytes, that VinOCRFromReader
+// and PlateImageRecognitionFromReader will send (default 10 MiB). Larger
+// images fail with ErrUploadTooLarge.
+func WithMaxUploadSize(n int64) Option {
+	return func(c *Client) {
+		if n > 0 {
+			c.maxUploadSize = n
+		}
+	}
+}
+
+// WithMaxInflightUploadBytes bounds the total size of POST bodies being
+// uploaded concurrently by the client. Uploads that would exceed the budget
+// wait, in arrival order, until enough in-flight uploads complete; a
+// cancelled context aborts the wait. A single body larger than n is allowed
+// through on its own once every other upload has finished.
+func WithMaxInflightUploadBytes(n int64) Option {
+	return func(c *Client) {
+		if n > 0 {
+			c.uploadBudget = newByteBudget(n)
+		}
+	}
+}
+
+// byteBudget is a FIFO weighted semaphore measured in bytes.
+type byteBudget struct {
+	mu      sync.Mutex
+	size    int64
+	used    int64
+	waiters list.List // of *budgetWaiter
+}
+
+type budgetWaiter struct {
+	n     int64
+	ready chan struct{}
+}
+
+func newByteBudget(size int64) *byteBudget {
+	return &byteBudget{size: size}
+}
+
+// acquire reserves n bytes, blocking until they are available or ctx is done.
+// It returns the number of bytes actually reserved, which must be passed to
+// release.
+func (b *byteBudget) acquire(ctx context.Context, n int64) (int64, error) {
+	if n > b.size {
+		n = b.size
+	}
+	b.mu.Lock()
+	if b.used+n <= b.size && b.waiters.Len() == 0 {
+		b.used += n
+		b.mu.Unlock()
+		return n, nil
+	}
+	w := &budgetWaiter{n: n, ready: make(chan struct{})}
+	elem := b.waiters.PushBack(w)
+	b.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return n, nil
+	case <-ctx.Done():
+		b.mu.Lock()
+		select {
+		case <-w.ready:
+			// Acquired just as ctx was cancelled; hand the bytes back.
+			b.used -= n
+			b.notifyLocked()
+		default:
+			front := b.waiters.Front() == elem
+			b.waiters.Remove(elem)
+			if front {
+				b.notifyLocked()
+			}
+		}
+		b.mu.Unlock()
+		return 0, ctx.Err()
+	}
+}
+
+// release returns n previously acquired bytes to the budget.
+func (b *byteBudget) release(n int64) {
+	b.mu.Lock()
+	b.used -= n
+	b.notifyLocked()
+	b.mu.Unlock()
+}
+
+// notifyLocked wakes queued waiters in order while their reservation fits.
+func (b *byteBudget) notifyLocked() {
+	for {
+		front := b.waiters.Front()
+		if front == nil {
+			return
+		}
+		w := front.Value.(*budgetWaiter)
+		if b.used+w.n > b.size {
+			return
+		}
+		b.used += w.n
+		b.waiters.Remove(front)
+		close(w.ready)
+	}
+}
+
+// postImageContext streams the image read from r to endpoint as a
+// multipart/form-data POST with the file in the "image" field. The content
+// type is sniffed from the first 512 bytes. Because the body is streamed,
+// the upload counts against WithMaxInflightUploadBytes with the maximum
+// upload size, and it is never retried.
+func (c *Client) postImageContext(ctx context.Context, endpoint string, r io.Reader, filename string, opts []CallOption) (map[string]any, error) {
+	ctx = ctxOrBackground(ctx)
+	br := bufio.NewReaderSize(r, 512)
+	head, err := br.Peek(512)
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("Failed to read image %s: %w", filename, err)
+	}
+	if len(head) == 0 {
+		return nil, fmt.Errorf("image %s is empty", filename)
+	}
+	contentType := http.DetectContentType(head)
+
+	limit := c.maxUploadSize
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		part, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Disposition": {fmt.Sprintf(`form-data; name="image"; filename=%q`, filepath.Base(filename))},
+			"Content-Type":        {contentType},
+		})
+		if err == nil {
+			var n int64
+			n, err = io.Copy(part, io.LimitReader(br, limit+1))
+			if err == nil && n > limit {
+				err = fmt.Errorf("%w: %s exceeds %d bytes", ErrUploadTooLarge, filename, limit)
+			}
+		}
+		if err == nil {
+			err = mw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+	defer pr.Close()
+
+	req, err := c.newRequest(ctx, http.MethodPost, endpoint, nil, pr)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	if c.uploadBudget != nil {
+		n, err := c.uploadBudget.acquire(ctx, limit)
+		if err != nil {
+			return nil, err
+		}
+		defer c.uploadBudget.release(n)
+	}
+	resp, err := c.doRequest(req, endpoint, newCallOptions(opts))
+	if err != nil {
+		if errors.Is(err, ErrUploadTooLarge) {
+			return nil, fmt.Errorf("%w: %s exceeds %d bytes", ErrUploadTooLarge, filename, limit)
+		}
+		return nil, err
+	}
+	return c.decodeResult(resp.Body)
+}
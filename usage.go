@@ -0,0 +1,136 @@
+package carsxe
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Usage is the credit usage CarsXE reported on a response.
+type Usage struct {
+	CreditsRemaining int
+	CreditsUsed      int
+	// ResetAt is when the quota resets; zero if not reported.
+	ResetAt time.Time
+}
+
+// Header names checked for each Usage field, in order.
+var (
+	usageRemainingHeaders = []string{"X-Credits-Remaining", "X-Quota-Remaining", "X-RateLimit-Remaining"}
+	usageUsedHeaders      = []string{"X-Credits-Used", "X-Quota-Used", "X-RateLimit-Used"}
+	usageResetHeaders     = []string{"X-Credits-Reset", "X-Quota-Reset", "X-RateLimit-Reset"}
+)
+
+// LastUsage returns the usage reported by the most recently completed
+// request that carried usage headers, whichever goroutine made it. It is the
+// zero Usage until then. Cached responses do not update it.
+func (c *Client) LastUsage() Usage {
+	c.usageMu.Lock()
+	defer c.usageMu.Unlock()
+	return c.usage
+}
+
+// recordUsage updates LastUsage and RateLimitState from the headers of a
+// response.
+func (c *Client) recordUsage(h http.Header) {
+	now := time.Now()
+	u, okUsage := parseUsage(h, now)
+	rl, okRate := parseRateLimit(h, now)
+	if !okUsage && !okRate {
+		return
+	}
+	c.usageMu.Lock()
+	if okUsage {
+		c.usage = u
+	}
+	if okRate {
+		c.rateLimit = rl
+	}
+	c.usageMu.Unlock()
+}
+
+// parseUsage reads a Usage from h.
+func parseUsage(h http.Header, now time.Time) (Usage, bool) {
+	var u Usage
+	found := false
+	if v, ok := headerInt(h, usageRemainingHeaders); ok {
+		u.CreditsRemaining, found = v, true
+	}
+	if v, ok := headerInt(h, usageUsedHeaders); ok {
+		u.CreditsUsed, found = v, true
+	}
+	for _, name := range usageResetHeaders {
+		if t, ok := parseReset(h.Get(name), now); ok {
+			u.ResetAt, found = t, true
+			break
+		}
+	}
+	return u, found
+}
+
+// parseReset parses a quota reset header, given as a Unix timestamp, a
+// number of seconds from now, or an HTTP or RFC 3339 date.
+func parseReset(v string, now time.Time) (time.Time, bool) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return time.Time{}, false
+	}
+	if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+		// Values this small are a delay rather than a timestamp.
+		if n < 1e9 {
+			return now.Add(time.Duration(n) * time.Second), true
+		}
+		return time.Unix(n, 0), true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return t, true
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// RateLimitState is the server-side rate limit reported by the
+// X-RateLimit-Limit, X-RateLimit-Remaining and X-RateLimit-Reset headers.
+type RateLimitState struct {
+	Limit     int
+	Remaining int
+	// Reset is when the window resets; zero if not reported.
+	Reset time.Time
+	// UpdatedAt is when the state was last updated; zero if no response
+	// has carried rate limit headers yet.
+	UpdatedAt time.Time
+}
+
+// RateLimitState returns the rate limit reported by the most recently
+// completed response that carried rate limit headers.
+func (c *Client) RateLimitState() RateLimitState {
+	c.usageMu.Lock()
+	defer c.usageMu.Unlock()
+	return c.rateLimit
+}
+
+// parseRateLimit reads a RateLimitState from h.
+func parseRateLimit(h http.Header, now time.Time) (RateLimitState, bool) {
+	var s RateLimitState
+	limit, okLimit := headerInt(h, []string{"X-RateLimit-Limit"})
+	remaining, okRemaining := headerInt(h, []string{"X-RateLimit-Remaining"})
+	reset, okReset := parseReset(h.Get("X-RateLimit-Reset"), now)
+	if !okLimit && !okRemaining && !okReset {
+		return s, false
+	}
+	s.Limit, s.Remaining, s.Reset, s.UpdatedAt = limit, remaining, reset, now
+	return s, true
+}
+
+// headerInt returns the first of names present in h as an int.
+func headerInt(h http.Header, names []string) (int, bool) {
+	for _, name := range names {
+		if n, err := strconv.Atoi(strings.TrimSpace(h.Get(name))); err == nil {
+			return n, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,257 @@
+package carsxe
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WMIInfo is what the offline World Manufacturer Identifier table knows
+// about the first three characters of a VIN.
+type WMIInfo struct {
+	WMI    string
+	Region string
+	// Country is empty when the WMI's country range is not known.
+	Country string
+	// Manufacturer is empty when the WMI is not in the table.
+	Manufacturer string
+}
+
+func (w WMIInfo) String() string {
+	parts := []string{w.WMI}
+	for _, p := range []string{w.Manufacturer, w.Country, w.Region} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+var (
+	wmiMu sync.RWMutex
+	// wmiManufacturers maps common WMIs to their manufacturer.
+	wmiManufacturers = map[string]string{
+		"19X": "Honda", "1B3": "Dodge", "1C3": "Chrysler", "1C4": "Jeep", "1C6": "Ram",
+		"1D7": "Dodge", "1FA": "Ford", "1FM": "Ford", "1FT": "Ford", "1G1": "Chevrolet",
+		"1G6": "Cadillac", "1GC": "Chevrolet", "1GT": "GMC", "1HG": "Honda", "1J4": "Jeep",
+		"1LN": "Lincoln", "1N4": "Nissan", "1N6": "Nissan", "2C3": "Chrysler", "2C4": "Chrysler",
+		"2FM": "Ford", "2G1": "Chevrolet", "2HG": "Honda", "2HK": "Honda", "2T1": "Toyota",
+		"2T3": "Toyota", "3FA": "Ford", "3GN": "Chevrolet", "3N1": "Nissan", "3VW": "Volkswagen",
+		"4JG": "Mercedes-Benz", "4S3": "Subaru", "4S4": "Subaru", "4T1": "Toyota", "4T3": "Toyota",
+		"5FN": "Honda", "5N1": "Nissan", "5NP": "Hyundai", "5UX": "BMW", "5XY": "Kia",
+		"5YJ": "Tesla", "7SA": "Tesla", "JA3": "Mitsubishi", "JA4": "Mitsubishi", "JF1": "Subaru",
+		"JF2": "Subaru", "JHM": "Honda", "JM1": "Mazda", "JN1": "Nissan", "JN8": "Nissan",
+		"JT2": "Toyota", "JTD": "Toyota", "JTE": "Toyota", "JTH": "Lexus", "KMH": "Hyundai",
+		"KNA": "Kia", "KND": "Kia", "KL1": "Chevrolet", "LVS": "Ford", "SAJ": "Jaguar",
+		"SAL": "Land Rover", "SCC": "Lotus", "SCF": "Aston Martin", "TRU": "Audi", "VF1": "Renault",
+		"VF3": "Peugeot", "VF7": "Citroën", "VSS": "SEAT", "WAU": "Audi", "WA1": "Audi",
+		"WBA": "BMW", "WBS": "BMW M", "WBY": "BMW i", "WDB": "Mercedes-Benz", "WDD": "Mercedes-Benz",
+		"WF0": "Ford", "WMW": "MINI", "WP0": "Porsche", "WP1": "Porsche", "WVW": "Volkswagen",
+		"WVG": "Volkswagen", "W0L": "Opel", "YS3": "Saab", "YV1": "Volvo", "YV4": "Volvo",
+		"ZAR": "Alfa Romeo", "ZFA": "Fiat", "ZFF": "Ferrari", "ZHW": "Lamborghini", "9BW": "Volkswagen",
+	}
+)
+
+// wmiCountries maps the first one or two VIN characters to a country. Two
+// character prefixes take precedence over single characters.
+var wmiCountries = map[string]string{
+	"1": "United States", "4": "United States", "5": "United States",
+	"2": "Canada", "3": "Mexico", "6": "Australia", "7": "New Zealand",
+	"J": "Japan", "KL": "South Korea", "KM": "South Korea", "KN": "South Korea",
+	"L": "China", "MA": "India", "ML": "Thailand", "NM": "Turkey",
+	"SA": "United Kingdom", "SB": "United Kingdom", "SC": "United Kingdom",
+	"TM": "Czech Republic", "TR": "Hungary", "U5": "Slovakia", "VF": "France",
+	"VR": "France", "VS": "Spain", "W": "Germany", "YS": "Sweden", "YV": "Sweden",
+	"Z": "Italy", "9B": "Brazil", "8A": "Argentina",
+}
+
+// RegisterWMI adds or replaces the manufacturer known for a WMI.
+func RegisterWMI(wmi, manufacturer string) {
+	wmiMu.Lock()
+	defer wmiMu.Unlock()
+	wmiManufacturers[strings.ToUpper(wmi)] = manufacturer
+}
+
+// LookupWMI decodes the World Manufacturer Identifier of vin offline. It
+// returns false when vin is too short to carry a WMI.
+func LookupWMI(vin string) (WMIInfo, bool) {
+	vin = strings.ToUpper(strings.TrimSpace(vin))
+	if len(vin) < 3 {
+		return WMIInfo{}, false
+	}
+	info := WMIInfo{WMI: vin[:3], Region: vinRegion(vin[0])}
+	if c, ok := wmiCountries[vin[:2]]; ok {
+		info.Country = c
+	} else {
+		info.Country = wmiCountries[vin[:1]]
+	}
+	wmiMu.RLock()
+	info.Manufacturer = wmiManufacturers[info.WMI]
+	wmiMu.RUnlock()
+	return info, true
+}
+
+// vinRegion maps the first VIN character to its ISO 3780 region.
+func vinRegion(c byte) string {
+	switch {
+	case c >= 'A' && c <= 'H':
+		return "Africa"
+	case c >= 'J' && c <= 'R':
+		return "Asia"
+	case c >= 'S' && c <= 'Z':
+		return "Europe"
+	case c >= '1' && c <= '5':
+		return "North America"
+	case c == '6' || c == '7':
+		return "Oceania"
+	case c == '8' || c == '9':
+		return "South America"
+	}
+	return ""
+}
+
+// VINInfo is what can be decoded from a 17-character VIN without calling
+// the API.
+type VINInfo struct {
+	VIN string
+	WMI WMIInfo
+	// VDS is the vehicle descriptor section (positions 4-8).
+	VDS        string
+	CheckDigit string
+	// ModelYear is derived from position 10, assuming the most recent
+	// 30-year cycle that is not in the future; 0 if unknown.
+	ModelYear int
+	// AssemblyPlant is the plant code at position 11.
+	AssemblyPlant string
+	// SequenceNumber is the production sequence number (positions 12-17).
+	SequenceNumber string
+}
+
+// modelYearCodes lists the position-10 codes in cycle order, starting with
+// 1980 (A) through 2000 (Y) and 2001 (1) through 2009 (9).
+const modelYearCodes = "ABCDEFGHJKLMNPRSTVWXY123456789"
+
+// DecodeVIN splits a 17-character VIN into its sections offline. It returns
+// false when vin does not have 17 characters; it does not validate the
+// check digit.
+func DecodeVIN(vin string) (VINInfo, bool) {
+	vin = strings.ToUpper(strings.TrimSpace(vin))
+	if len(vin) != 17 {
+		return VINInfo{}, false
+	}
+	info := VINInfo{
+		VIN:            vin,
+		VDS:            vin[3:8],
+		CheckDigit:     vin[8:9],
+		ModelYear:      modelYear(vin[9], time.Now().Year()+1),
+		AssemblyPlant:  vin[10:11],
+		SequenceNumber: vin[11:],
+	}
+	info.WMI, _ = LookupWMI(vin)
+	return info, true
+}
+
+// modelYear maps a position-10 code to the latest matching year not after
+// maxYear.
+func modelYear(code byte, maxYear int) int {
+	i := strings.IndexByte(modelYearCodes, code)
+	if i < 0 {
+		return 0
+	}
+	year := 1980 + i
+	for year+30 <= maxYear {
+		year += 30
+	}
+	return year
+}
+
+// ErrInvalidVIN is returned by ValidateVIN and, with WithVINValidation, by
+// the VIN-based endpoint methods before any HTTP call.
+var ErrInvalidVIN = errors.New("carsxe: invalid VIN")
+
+// vinWeights are the ISO 3779 check digit position weights.
+var vinWeights = [17]int{8, 7, 6, 5, 4, 3, 2, 10, 0, 9, 8, 7, 6, 5, 4, 3, 2}
+
+// vinValue is the transliterated value of a VIN character, or -1 if the
+// character is not allowed.
+func vinValue(r byte) int {
+	switch {
+	case r >= '0' && r <= '9':
+		return int(r - '0')
+	case r == 'I' || r == 'O' || r == 'Q':
+		return -1
+	case r >= 'A' && r <= 'H':
+		return int(r-'A') + 1
+	case r >= 'J' && r <= 'R':
+		return int(r-'J') + 1
+	case r >= 'S' && r <= 'Z':
+		return int(r-'S') + 2
+	}
+	return -1
+}
+
+// ValidateVIN checks that vin is a well-formed 17-character VIN: only
+// letters and digits other than I, O and Q, with a valid ISO 3779 check
+// digit at position 9. Pre-1981 VINs, which were not standardized, are
+// accepted when they have 5 to 16 letters and digits. The returned error
+// wraps ErrInvalidVIN.
+func ValidateVIN(vin string) error {
+	vin = strings.ToUpper(strings.TrimSpace(vin))
+	if len(vin) < 17 {
+		if len(vin) < 5 {
+			return fmt.Errorf("%w: %q is too short", ErrInvalidVIN, vin)
+		}
+		for i := 0; i < len(vin); i++ {
+			if r := vin[i]; !(r >= '0' && r <= '9' || r >= 'A' && r <= 'Z') {
+				return fmt.Errorf("%w: %q contains %q", ErrInvalidVIN, vin, r)
+			}
+		}
+		return nil
+	}
+	if len(vin) > 17 {
+		return fmt.Errorf("%w: %q has %d characters, want 17", ErrInvalidVIN, vin, len(vin))
+	}
+	sum := 0
+	for i := 0; i < 17; i++ {
+		n := vinValue(vin[i])
+		if n < 0 {
+			return fmt.Errorf("%w: %q contains %q at position %d", ErrInvalidVIN, vin, vin[i], i+1)
+		}
+		sum += n * vinWeights[i]
+	}
+	want := byte('0' + sum%11)
+	if sum%11 == 10 {
+		want = 'X'
+	}
+	if vin[8] != want {
+		return fmt.Errorf("%w: %q has check digit %q, want %q", ErrInvalidVIN, vin, vin[8], want)
+	}
+	return nil
+}
+
+// WithVINValidation makes the Specs, MarketValue, History and Recalls
+// methods check the "vin" parameter with ValidateVIN and return the error
+// before calling the API. The map-based variants panic with it, like any
+// other error.
+func WithVINValidation() Option {
+	return func(c *Client) { c.validateVINs = true }
+}
+
+// WithStrictValidation enables every client-side check: WithVINValidation
+// and WithParamValidation.
+func WithStrictValidation() Option {
+	return func(c *Client) {
+		c.validateVINs = true
+		c.validateParams = true
+	}
+}
+
+// checkVIN validates params["vin"] when WithVINValidation is set.
+func (c *Client) checkVIN(params map[string]string) error {
+	if !c.validateVINs {
+		return nil
+	}
+	return ValidateVIN(params["vin"])
+}
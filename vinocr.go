@@ -0,0 +1,167 @@
+package carsxe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// VinOCRResult is the typed outcome of a VIN OCR request.
+type VinOCRResult struct {
+	VIN        string
+	Confidence float64
+	Candidates []VinOCRCandidate
+	// Raw is the decoded response body.
+	Raw map[string]any
+	// Err is set when the image could not be processed.
+	Err error
+}
+
+// VinOCRCandidate is one possible VIN read from an image.
+type VinOCRCandidate struct {
+	VIN        string
+	Confidence float64
+}
+
+// newVinOCRResult maps a VinOCR response onto VinOCRResult.
+func newVinOCRResult(raw map[string]any) VinOCRResult {
+	r := VinOCRResult{Raw: raw}
+	body := raw
+	if data := asMap(raw["data"]); data != nil {
+		body = data
+	}
+	r.VIN = toString(body["vin"])
+	r.Confidence, _ = toFloat(body["confidence"])
+	for _, item := range asSlice(body["candidates"]) {
+		m := asMap(item)
+		if m == nil {
+			continue
+		}
+		cand := VinOCRCandidate{VIN: toString(m["vin"])}
+		cand.Confidence, _ = toFloat(m["confidence"])
+		r.Candidates = append(r.Candidates, cand)
+	}
+	return r
+}
+
+// VinOCRFromReader runs VIN OCR on image data read from r, streaming it as
+// a multipart/form-data upload instead of the JSON body used by VinOCR.
+// filename names the uploaded file and appears in error messages. Images
+// larger than the maximum upload size (see WithMaxUploadSize) fail with
+// ErrUploadTooLarge.
+func (c *Client) VinOCRFromReader(ctx context.Context, r io.Reader, filename string, opts ...CallOption) (map[string]any, error) {
+	return c.postImageContext(ctx, "v1/vinocr", r, filename, opts)
+}
+
+// PlateImageRecognitionFromReader is like VinOCRFromReader for the plate
+// recognition endpoint.
+func (c *Client) PlateImageRecognitionFromReader(ctx context.Context, r io.Reader, filename string, opts ...CallOption) (map[string]any, error) {
+	return c.postImageContext(ctx, "platerecognition", r, filename, opts)
+}
+
+// VinOCRFromFile runs VIN OCR on the image file at path, streaming it like
+// VinOCRFromReader. Files larger than the maximum upload size fail with
+// ErrUploadTooLarge before anything is sent.
+func (c *Client) VinOCRFromFile(ctx context.Context, path string, opts ...CallOption) (map[string]any, error) {
+	return c.postImageFile(ctx, "v1/vinocr", path, opts)
+}
+
+// PlateImageRecognitionFromFile is like VinOCRFromFile for the plate
+// recognition endpoint.
+func (c *Client) PlateImageRecognitionFromFile(ctx context.Context, path string, opts ...CallOption) (map[string]any, error) {
+	return c.postImageFile(ctx, "platerecognition", path, opts)
+}
+
+// postImageFile uploads the file at path to endpoint, checking its size
+// first.
+func (c *Client) postImageFile(ctx context.Context, endpoint, path string, opts []CallOption) (map[string]any, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if !fi.Mode().IsRegular() {
+		return nil, fmt.Errorf("%s is not a regular file", path)
+	}
+	if fi.Size() > c.maxUploadSize {
+		return nil, fmt.Errorf("%w: %s exceeds %d bytes", ErrUploadTooLarge, filepath.Base(path), c.maxUploadSize)
+	}
+	return c.postImageContext(ctx, endpoint, f, filepath.Base(path), opts)
+}
+
+// imageExtensions lists the file extensions VinOCRDir treats as images.
+var imageExtensions = map[string]bool{
+	".jpg": true, ".jpeg": true, ".png": true, ".gif": true, ".bmp": true,
+	".webp": true, ".tif": true, ".tiff": true, ".heic": true,
+}
+
+// VinOCRDir runs VIN OCR on every image file directly inside dir, with at
+// most concurrency uploads at once. Files without an image extension and
+// subdirectories are skipped. Results are keyed by file name; per-file
+// failures are reported in VinOCRResult.Err. The returned error is non-nil
+// only when dir cannot be read or ctx is cancelled, in which case the results
+// gathered so far are still returned.
+func (c *Client) VinOCRDir(ctx context.Context, dir string, concurrency int, opts ...CallOption) (map[string]VinOCRResult, error) {
+	ctx = ctxOrBackground(ctx)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if e.Type().IsRegular() && imageExtensions[strings.ToLower(filepath.Ext(e.Name()))] {
+			names = append(names, e.Name())
+		}
+	}
+	if concurrency <= 0 {
+		concurrency = defaultBatchConcurrency
+	}
+
+	var (
+		mu      sync.Mutex
+		results = make(map[string]VinOCRResult, len(names))
+		wg      sync.WaitGroup
+		sem     = make(chan struct{}, concurrency)
+	)
+	for _, name := range names {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return results, ctx.Err()
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			res := c.vinOCRFile(ctx, filepath.Join(dir, name), opts)
+			mu.Lock()
+			results[name] = res
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return results, ctx.Err()
+}
+
+// vinOCRFile uploads the image at path and maps the outcome to a result.
+func (c *Client) vinOCRFile(ctx context.Context, path string, opts []CallOption) VinOCRResult {
+	raw, err := c.VinOCRFromFile(ctx, path, opts...)
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			return VinOCRResult{Raw: apiErr.decoded, Err: err}
+		}
+		return VinOCRResult{Err: err}
+	}
+	return newVinOCRResult(raw)
+}
@@ -0,0 +1,233 @@
+// Package webhook receives CarsXE webhook deliveries, such as the results of
+// asynchronous bulk decodes or vehicle monitoring alerts.
+//
+// A delivery is a POST whose JSON body is an Event envelope and whose
+// SignatureHeader carries the hex-encoded HMAC-SHA256 of the body keyed
+// with the webhook secret, optionally prefixed with "sha256=". The signature
+// only covers the body, so a captured delivery stays valid: set a replay
+// window with Handler.SetReplayWindow to reject old and repeated ones.
+//
+//	h := webhook.NewHandler(secret)
+//	h.On("bulk.completed", func(ctx context.Context, e webhook.Event) error {
+//		var job struct{ JobID string `json:"job_id"` }
+//		return e.Decode(&job)
+//	})
+//	http.Handle("/carsxe/webhook", h)
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SignatureHeader is the request header holding the delivery's signature.
+const SignatureHeader = "X-CarsXE-Signature"
+
+// maxBodyBytes caps the size of a delivery read by Handler.
+const maxBodyBytes = 5 << 20
+
+// ErrInvalidSignature is returned by Verify when a signature is missing or
+// does not match the body.
+var ErrInvalidSignature = errors.New("webhook: invalid signature")
+
+// ErrNoSecret is returned by Verify when the secret is empty, since anyone
+// could then sign a delivery.
+var ErrNoSecret = errors.New("webhook: no secret configured")
+
+// ErrStaleEvent reports a delivery created outside the replay window set
+// with SetReplayWindow.
+var ErrStaleEvent = errors.New("webhook: event outside the replay window")
+
+// Event is a webhook delivery.
+type Event struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Decode unmarshals the event's data into v.
+func (e Event) Decode(v any) error {
+	if len(e.Data) == 0 {
+		return fmt.Errorf("webhook: event %s has no data", e.ID)
+	}
+	return json.Unmarshal(e.Data, v)
+}
+
+// HandlerFunc processes an event. Returning an error makes the delivery
+// fail with a 500 so that CarsXE retries it.
+type HandlerFunc func(ctx context.Context, e Event) error
+
+// Handler is an http.Handler that verifies deliveries and dispatches them
+// to the callbacks registered per event type. Events of a type without a
+// callback are acknowledged and dropped.
+type Handler struct {
+	secret []byte
+
+	mu       sync.RWMutex
+	handlers map[string]HandlerFunc
+	fallback HandlerFunc
+
+	replayMu sync.Mutex
+	window   time.Duration
+	handled  map[string]time.Time // event ID -> when it was handled
+}
+
+// NewHandler returns a Handler verifying deliveries with secret. With an
+// empty secret every delivery is refused with a 500.
+func NewHandler(secret string) *Handler {
+	return &Handler{secret: []byte(secret), handlers: map[string]HandlerFunc{}}
+}
+
+// SetReplayWindow protects against replayed deliveries: events whose
+// created_at is more than d away from now are refused with a 401, and an
+// event ID already handled successfully within d is acknowledged again
+// without calling its callback. Deliveries whose callback failed are not
+// remembered, so CarsXE's retries are still processed. A d of zero, the
+// default, turns the protection off.
+func (h *Handler) SetReplayWindow(d time.Duration) {
+	h.replayMu.Lock()
+	defer h.replayMu.Unlock()
+	h.window = max(d, 0)
+	h.handled = map[string]time.Time{}
+}
+
+// On registers fn for events of the given type, replacing any previous
+// callback for it.
+func (h *Handler) On(eventType string, fn HandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handlers[eventType] = fn
+}
+
+// OnAny registers fn for events whose type has no callback of its own.
+func (h *Handler) OnAny(fn HandlerFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.fallback = fn
+}
+
+// ServeHTTP verifies, parses and dispatches a delivery. It answers 405 for
+// non-POST requests, 401 for a bad signature or an event outside the replay
+// window, 400 for a malformed body and 500 when no secret is configured or
+// the callback fails.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		http.Error(w, "cannot read body", http.StatusBadRequest)
+		return
+	}
+	if err := Verify(h.secret, body, r.Header.Get(SignatureHeader)); err != nil {
+		status := http.StatusUnauthorized
+		if errors.Is(err, ErrNoSecret) {
+			status = http.StatusInternalServerError
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	var e Event
+	if err := json.Unmarshal(body, &e); err != nil || e.Type == "" {
+		http.Error(w, "malformed event", http.StatusBadRequest)
+		return
+	}
+	seen, err := h.checkReplay(e)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if seen {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	h.mu.RLock()
+	fn, ok := h.handlers[e.Type]
+	if !ok {
+		fn = h.fallback
+	}
+	h.mu.RUnlock()
+	if fn != nil {
+		if err := fn(r.Context(), e); err != nil {
+			http.Error(w, "event handler failed", http.StatusInternalServerError)
+			return
+		}
+	}
+	h.markHandled(e)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// checkReplay enforces the replay window, reporting whether e was already
+// handled.
+func (h *Handler) checkReplay(e Event) (seen bool, err error) {
+	h.replayMu.Lock()
+	defer h.replayMu.Unlock()
+	if h.window <= 0 {
+		return false, nil
+	}
+	if e.CreatedAt.IsZero() || time.Since(e.CreatedAt).Abs() > h.window {
+		return false, ErrStaleEvent
+	}
+	_, seen = h.handled[e.ID]
+	return seen, nil
+}
+
+// markHandled remembers e's ID for the replay window, forgetting IDs that
+// have left it.
+func (h *Handler) markHandled(e Event) {
+	h.replayMu.Lock()
+	defer h.replayMu.Unlock()
+	if h.window <= 0 || e.ID == "" {
+		return
+	}
+	now := time.Now()
+	for id, at := range h.handled {
+		if now.Sub(at) > h.window {
+			delete(h.handled, id)
+		}
+	}
+	h.handled[e.ID] = now
+}
+
+// Verify checks signature, as found in SignatureHeader, against body. It
+// fails with ErrNoSecret when secret is empty.
+func Verify(secret, body []byte, signature string) error {
+	if len(secret) == 0 {
+		return ErrNoSecret
+	}
+	signature = strings.TrimPrefix(strings.TrimSpace(signature), "sha256=")
+	got, err := hex.DecodeString(signature)
+	if err != nil || len(got) == 0 {
+		return ErrInvalidSignature
+	}
+	if !hmac.Equal(got, mac(secret, body)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// Sign returns the SignatureHeader value for body, e.g. to send test
+// deliveries to a Handler.
+func Sign(secret, body []byte) string {
+	return "sha256=" + hex.EncodeToString(mac(secret, body))
+}
+
+func mac(secret, body []byte) []byte {
+	m := hmac.New(sha256.New, secret)
+	m.Write(body)
+	return m.Sum(nil)
+}
@@ -0,0 +1,110 @@
+package carsxe
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrTimeBudgetExceeded is returned by Workflow.Step once the workflow's time
+// budget has been spent.
+var ErrTimeBudgetExceeded = errors.New("carsxe: workflow time budget exceeded")
+
+// Workflow runs a chain of dependent calls (e.g. plate -> VIN -> specs ->
+// market value) under one overall deadline instead of a timeout per call.
+// Each step receives the shared context, so a slow early step leaves less
+// time for the rest; once the budget is spent remaining steps are skipped.
+// Results are collected by the step functions themselves, so whatever was
+// fetched before the budget ran out remains available:
+//
+//	wf := carsxe.NewWorkflow(ctx, 3*time.Second)
+//	defer wf.Close()
+//	var plate, specs map[string]any
+//	err := wf.Step("plate", func(ctx context.Context) (err error) {
+//		plate, err = client.PlateDecoderContext(ctx, params)
+//		return err
+//	})
+//	...
+//	if wf.BudgetExceeded() { /* serve the partial result */ }
+type Workflow struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu       sync.Mutex
+	steps    []WorkflowStep
+	exceeded bool
+}
+
+// WorkflowStep records the outcome of one Workflow step.
+type WorkflowStep struct {
+	Name     string
+	Duration time.Duration
+	Err      error
+	// Skipped is true when the step did not run because the budget was
+	// already spent.
+	Skipped bool
+}
+
+// NewWorkflow starts a workflow whose steps must all finish within budget.
+// Close must be called to release its resources.
+func NewWorkflow(ctx context.Context, budget time.Duration) *Workflow {
+	ctx = ctxOrBackground(ctx)
+	wctx, cancel := context.WithTimeout(ctx, budget)
+	return &Workflow{ctx: wctx, cancel: cancel}
+}
+
+// Context returns the context shared by all steps.
+func (w *Workflow) Context() context.Context { return w.ctx }
+
+// Step runs fn with the workflow context unless the budget is already spent,
+// in which case it returns ErrTimeBudgetExceeded without calling fn. Errors
+// caused by the budget running out during fn are also reported as
+// ErrTimeBudgetExceeded (wrapping the original error).
+func (w *Workflow) Step(name string, fn func(ctx context.Context) error) error {
+	if w.spent() {
+		w.record(WorkflowStep{Name: name, Err: ErrTimeBudgetExceeded, Skipped: true})
+		return ErrTimeBudgetExceeded
+	}
+	start := time.Now()
+	err := fn(w.ctx)
+	if err != nil && w.spent() {
+		err = errors.Join(ErrTimeBudgetExceeded, err)
+	}
+	w.record(WorkflowStep{Name: name, Duration: time.Since(start), Err: err})
+	return err
+}
+
+// BudgetExceeded reports whether the time budget ran out.
+func (w *Workflow) BudgetExceeded() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.exceeded
+}
+
+// Steps returns the steps run or skipped so far, in order.
+func (w *Workflow) Steps() []WorkflowStep {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]WorkflowStep(nil), w.steps...)
+}
+
+// Close releases the workflow's timer.
+func (w *Workflow) Close() { w.cancel() }
+
+// spent reports whether the deadline has passed, remembering it if so.
+func (w *Workflow) spent() bool {
+	if !errors.Is(w.ctx.Err(), context.DeadlineExceeded) {
+		return false
+	}
+	w.mu.Lock()
+	w.exceeded = true
+	w.mu.Unlock()
+	return true
+}
+
+func (w *Workflow) record(s WorkflowStep) {
+	w.mu.Lock()
+	w.steps = append(w.steps, s)
+	w.mu.Unlock()
+}
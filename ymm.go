@@ -0,0 +1,100 @@
+package carsxe
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// YMMResult is the typed form of a YearMakeModel response.
+type YMMResult struct {
+	// BestMatch is the trim the API considers the closest match.
+	BestMatch YMMTrim
+	// Trims lists every trim available for the year, make and model.
+	Trims []YMMTrim
+	// Raw is the decoded response body.
+	Raw map[string]any
+}
+
+// YMMTrim describes one trim of a vehicle.
+type YMMTrim struct {
+	Year        int
+	Make        string
+	Model       string
+	Trim        string
+	Description string
+	MSRP        float64
+	Raw         map[string]any
+}
+
+// YearMakeModelTyped is like YearMakeModel but honors ctx, returns errors
+// and decodes the response into a YMMResult.
+func (c *Client) YearMakeModelTyped(ctx context.Context, params map[string]string, opts ...CallOption) (*YMMResult, error) {
+	raw, err := c.YearMakeModelContext(ctx, params, opts...)
+	if err != nil {
+		return nil, err
+	}
+	r := &YMMResult{Raw: raw}
+	if m := asMap(firstValue(raw, "bestMatch", "best_match")); m != nil {
+		r.BestMatch = newYMMTrim(m)
+	}
+	for _, item := range asSlice(firstValue(raw, "allTrims", "all_trims", "trims")) {
+		if m := asMap(item); m != nil {
+			r.Trims = append(r.Trims, newYMMTrim(m))
+		}
+	}
+	return r, nil
+}
+
+// Trims lists the trims available for a year, make and model, sorted by
+// name without duplicates, e.g. to populate the last of a set of cascading
+// selectors. It is backed by the YearMakeModel endpoint, so responses are
+// cached like any other GET under WithCache. Year, make and model default
+// to the values queried when the API leaves them out.
+func (c *Client) Trims(ctx context.Context, year int, vehicleMake, model string, opts ...CallOption) ([]YMMTrim, error) {
+	r, err := c.YearMakeModelTyped(ctx, map[string]string{
+		"year": strconv.Itoa(year), "make": vehicleMake, "model": model,
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	trims := r.Trims
+	if len(trims) == 0 && r.BestMatch.Trim != "" {
+		trims = []YMMTrim{r.BestMatch}
+	}
+	seen := map[string]bool{}
+	var out []YMMTrim
+	for _, t := range trims {
+		key := strings.ToLower(strings.TrimSpace(t.Trim))
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		if t.Year == 0 {
+			t.Year = year
+		}
+		if t.Make == "" {
+			t.Make = vehicleMake
+		}
+		if t.Model == "" {
+			t.Model = model
+		}
+		out = append(out, t)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return strings.ToLower(out[i].Trim) < strings.ToLower(out[j].Trim) })
+	return out, nil
+}
+
+func newYMMTrim(m map[string]any) YMMTrim {
+	t := YMMTrim{
+		Make:        firstString(m, "make"),
+		Model:       firstString(m, "model"),
+		Trim:        firstString(m, "trim", "name"),
+		Description: firstString(m, "description"),
+		Raw:         m,
+	}
+	t.Year, _ = toInt(m["year"])
+	t.MSRP, _ = toFloat(firstValue(m, "msrp", "price"))
+	return t
+}